@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"text/template"
 
+	"github.com/mercari/tfnotify/v1/pkg/terraform"
 	"github.com/sirupsen/logrus"
 )
 
@@ -48,6 +49,9 @@ type PlanData struct {
 	Warning                string
 	ChangeOutsideTerraform string
 	ErrorMessages          []string
+	Diagnostics            []*terraform.Diagnostic
+	AppliedResources       []*terraform.AppliedResource
+	OutputChanges          []*terraform.OutputChange
 	ExitCode               int
 	CombinedOutput         string
 	PRNumber               int
@@ -98,6 +102,9 @@ func (s *Summarizer) GenerateSummary(ctx context.Context, data interface{}) (str
 		Warning:                getString(planDataMap, "Warning"),
 		ChangeOutsideTerraform: getString(planDataMap, "ChangeOutsideTerraform"),
 		ErrorMessages:          getStringSlice(planDataMap, "ErrorMessages"),
+		Diagnostics:            getDiagnostics(planDataMap, "Diagnostics"),
+		AppliedResources:       getAppliedResources(planDataMap, "AppliedResources"),
+		OutputChanges:          getOutputChanges(planDataMap, "OutputChanges"),
 		ExitCode:               getInt(planDataMap, "ExitCode"),
 		CombinedOutput:         getString(planDataMap, "CombinedOutput"),
 		PRNumber:               getInt(planDataMap, "PRNumber"),
@@ -169,6 +176,33 @@ func getStringSlice(m map[string]interface{}, key string) []string {
 	return nil
 }
 
+func getDiagnostics(m map[string]interface{}, key string) []*terraform.Diagnostic {
+	if v, ok := m[key]; ok {
+		if diags, ok := v.([]*terraform.Diagnostic); ok {
+			return diags
+		}
+	}
+	return nil
+}
+
+func getAppliedResources(m map[string]interface{}, key string) []*terraform.AppliedResource {
+	if v, ok := m[key]; ok {
+		if resources, ok := v.([]*terraform.AppliedResource); ok {
+			return resources
+		}
+	}
+	return nil
+}
+
+func getOutputChanges(m map[string]interface{}, key string) []*terraform.OutputChange {
+	if v, ok := m[key]; ok {
+		if changes, ok := v.([]*terraform.OutputChange); ok {
+			return changes
+		}
+	}
+	return nil
+}
+
 func getBool(m map[string]interface{}, key string) bool {
 	if v, ok := m[key]; ok {
 		if b, ok := v.(bool); ok {
@@ -70,9 +70,31 @@ func New(flags *LDFlags) *cli.Command {
 				Usage:     "Run terraform plan and post a comment to GitHub commit, pull request, or issue",
 				Description: `Run terraform plan and post a comment to GitHub commit, pull request, or issue.
 
-$ tfnotify [<global options>] plan [-patch] [-skip-no-changes] -- terraform plan [<terraform plan options>]`,
+$ tfnotify [<global options>] plan [-patch] [-skip-no-changes] -- terraform plan [<terraform plan options>]
+$ terraform plan | tfnotify [<global options>] plan --input - [--exit-code <exit code>]
+$ tfnotify [<global options>] plan --plan-file <plan file> [-- terraform [<terraform options>]]`,
 				Action: cmdPlan,
 				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "input",
+						Usage: "read the output of terraform plan from the file instead of running a command. \"-\" means the standard input",
+					},
+					&cli.IntFlag{
+						Name:  "exit-code",
+						Usage: "the exit code of the command which printed the input. It is used with --input",
+					},
+					&cli.StringFlag{
+						Name:  "record",
+						Usage: "save the captured output, the CI information, and variables to the file. The output is masked. The file can be notified again with tfnotify replay",
+					},
+					&cli.StringFlag{
+						Name:  "plan-file",
+						Usage: "notify the plan file created by terraform plan -out. terraform show and terraform show -json are run for the plan file",
+					},
+					&cli.StringFlag{
+						Name:  "infracost",
+						Usage: "the path of the JSON output of infracost breakdown or infracost diff. The monthly cost is rendered in the comment",
+					},
 					&cli.BoolFlag{
 						Name:    "patch",
 						Usage:   "update an existing comment instead of creating a new comment. If there is no existing comment, a new comment is created.",
@@ -95,7 +117,7 @@ $ tfnotify [<global options>] plan [-patch] [-skip-no-changes] -- terraform plan
 					},
 					&cli.BoolFlag{
 						Name:    "consolidated",
-						Usage:   "For Terragrunt: consolidate all module results into a single comment instead of posting per module",
+						Usage:   "For Terragrunt: render the results per module in the comment. Terragrunt output is detected automatically",
 						Sources: cli.EnvVars("TFNOTIFY_CONSOLIDATED"),
 					},
 					&cli.BoolFlag{
@@ -132,12 +154,30 @@ $ tfnotify [<global options>] plan [-patch] [-skip-no-changes] -- terraform plan
 				Usage:     "Run terraform apply and post a comment to GitHub commit, pull request, or issue",
 				Description: `Run terraform apply and post a comment to GitHub commit, pull request, or issue.
 
-$ tfnotify [<global options>] apply -- terraform apply [<terraform apply options>]`,
+$ tfnotify [<global options>] apply -- terraform apply [<terraform apply options>]
+$ terraform apply | tfnotify [<global options>] apply --input - [--exit-code <exit code>]`,
 				Action: cmdApply,
 				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "input",
+						Usage: "read the output of terraform apply from the file instead of running a command. \"-\" means the standard input",
+					},
+					&cli.IntFlag{
+						Name:  "exit-code",
+						Usage: "the exit code of the command which printed the input. It is used with --input",
+					},
+					&cli.StringFlag{
+						Name:  "record",
+						Usage: "save the captured output, the CI information, and variables to the file. The output is masked. The file can be notified again with tfnotify replay",
+					},
+					&cli.BoolFlag{
+						Name:    "disable-label",
+						Usage:   "Disable to replace the plan labels with the label of the apply result",
+						Sources: cli.EnvVars("TFNOTIFY_DISABLE_LABEL"),
+					},
 					&cli.BoolFlag{
 						Name:    "consolidated",
-						Usage:   "For Terragrunt: consolidate all module results into a single comment instead of posting per module",
+						Usage:   "For Terragrunt: render the results per module in the comment. Terragrunt output is detected automatically",
 						Sources: cli.EnvVars("TFNOTIFY_CONSOLIDATED"),
 					},
 					&cli.BoolFlag{
@@ -168,6 +208,96 @@ $ tfnotify [<global options>] apply -- terraform apply [<terraform apply options
 					},
 				},
 			},
+			{
+				Name:      "validate",
+				ArgsUsage: " <command> <args>...",
+				Usage:     "Run terraform validate and post a comment to GitHub commit, pull request, or issue",
+				Description: `Run terraform validate and post a comment to GitHub commit, pull request, or issue.
+If the configuration is valid and there is no warning, no comment is posted.
+
+$ tfnotify [<global options>] validate [-patch] -- terraform validate -json`,
+				Action: cmdValidate,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:    "patch",
+						Usage:   "update an existing comment instead of creating a new comment. If there is no existing comment, a new comment is created.",
+						Sources: cli.EnvVars("TFNOTIFY_VALIDATE_PATCH"),
+					},
+					&cli.BoolFlag{
+						Name:    "disable-label",
+						Usage:   "Disable to add or remove a label",
+						Sources: cli.EnvVars("TFNOTIFY_DISABLE_LABEL"),
+					},
+				},
+			},
+			{
+				Name:      "fmt",
+				ArgsUsage: " <command> <args>...",
+				Usage:     "Run terraform fmt and post a comment to GitHub commit, pull request, or issue",
+				Description: `Run terraform fmt and post a comment to GitHub commit, pull request, or issue.
+If all files are formatted, no comment is posted.
+
+$ tfnotify [<global options>] fmt [-patch] -- terraform fmt -check -diff -recursive`,
+				Action: cmdFmt,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:    "patch",
+						Usage:   "update an existing comment instead of creating a new comment. If there is no existing comment, a new comment is created.",
+						Sources: cli.EnvVars("TFNOTIFY_FMT_PATCH"),
+					},
+				},
+			},
+			{
+				Name:      "drift",
+				ArgsUsage: " <command> <args>...",
+				Usage:     "Run terraform plan -refresh-only and track drift with a GitHub issue",
+				Description: `Run terraform plan -refresh-only and track drift with a GitHub issue.
+An issue is opened or updated per target when drift is detected, and it is closed when the drift goes away.
+
+$ tfnotify [<global options>] drift -- terraform plan -refresh-only -detailed-exitcode`,
+				Action: cmdDrift,
+			},
+			{
+				Name:      "test",
+				ArgsUsage: " <command> <args>...",
+				Usage:     "Run terraform test and post a comment to GitHub commit, pull request, or issue",
+				Description: `Run terraform test and post a comment to GitHub commit, pull request, or issue.
+The comment has a table of the results of run blocks and the details of failures.
+
+$ tfnotify [<global options>] test [-patch] -- terraform test`,
+				Action: cmdTest,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:    "patch",
+						Usage:   "update an existing comment instead of creating a new comment. If there is no existing comment, a new comment is created.",
+						Sources: cli.EnvVars("TFNOTIFY_TEST_PATCH"),
+					},
+				},
+			},
+			{
+				Name:      "replay",
+				ArgsUsage: " <record file>",
+				Usage:     "Notify a run saved by --record again",
+				Description: `Notify a run of plan or apply saved by --record again without running the command.
+This is useful when a comment fails to be posted because of a token or a rate limit.
+The CI information and variables in the record are used. Variables given by --var take precedence.
+
+$ tfnotify [<global options>] plan --record run.tfnotify.json -- terraform plan
+$ tfnotify [<global options>] replay run.tfnotify.json`,
+				Action: cmdReplay,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:    "patch",
+						Usage:   "update an existing comment instead of creating a new comment. If there is no existing comment, a new comment is created.",
+						Sources: cli.EnvVars("TFNOTIFY_PLAN_PATCH"),
+					},
+					&cli.BoolFlag{
+						Name:    "disable-label",
+						Usage:   "Disable to add or update a label",
+						Sources: cli.EnvVars("TFNOTIFY_DISABLE_LABEL"),
+					},
+				},
+			},
 			vcmd.New(&vcmd.Command{
 				Name:    "tfnotify",
 				Version: flags.Version,
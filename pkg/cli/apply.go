@@ -53,17 +53,9 @@ func cmdApply(ctx context.Context, cmd *cli.Command) error {
 		logrus.Info("Terragrunt consolidated mode enabled")
 	}
 
-	// Select parser based on configuration
-	var parser terraform.Parser
-	if cfg.Terraform.Consolidated {
-		parser = terraform.NewTerragruntParser(true)
-	} else {
-		parser = terraform.NewApplyParser()
-	}
-
 	t := &controller.Controller{
 		Config:             cfg,
-		Parser:             parser,
+		Parser:             terraform.NewDetectingApplyParser(cfg.Terraform.Consolidated),
 		Template:           terraform.NewApplyTemplate(cfg.Terraform.Apply.Template),
 		ParseErrorTemplate: terraform.NewApplyParseErrorTemplate(cfg.Terraform.Apply.WhenParseError.Template),
 	}
@@ -101,7 +93,10 @@ func cmdApply(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args()
 
 	return t.Apply(ctx, controller.Command{
-		Cmd:  args.First(),
-		Args: args.Tail(),
+		Cmd:      args.First(),
+		Args:     args.Tail(),
+		Input:    cmd.String("input"),
+		ExitCode: cmd.Int("exit-code"),
+		Record:   cmd.String("record"),
 	})
 }
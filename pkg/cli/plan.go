@@ -2,9 +2,11 @@ package cli
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	"github.com/mercari/tfnotify/v1/pkg/ai"
+	"github.com/mercari/tfnotify/v1/pkg/config"
 	"github.com/mercari/tfnotify/v1/pkg/controller"
 	"github.com/mercari/tfnotify/v1/pkg/terraform"
 	"github.com/sirupsen/logrus"
@@ -53,11 +55,9 @@ func cmdPlan(ctx context.Context, cmd *cli.Command) error {
 	}
 
 	// Select parser based on configuration
-	var parser terraform.Parser
-	if cfg.Terraform.Consolidated {
-		parser = terraform.NewTerragruntParser(true)
-	} else {
-		parser = terraform.NewPlanParser()
+	parser, err := newPlanParser(&cfg)
+	if err != nil {
+		return err
 	}
 
 	t := &controller.Controller{
@@ -99,7 +99,27 @@ func cmdPlan(ctx context.Context, cmd *cli.Command) error {
 	args := cmd.Args()
 
 	return t.Plan(ctx, controller.Command{
-		Cmd:  args.First(),
-		Args: args.Tail(),
+		Cmd:      args.First(),
+		Args:     args.Tail(),
+		Input:    cmd.String("input"),
+		ExitCode: cmd.Int("exit-code"),
+		Record:   cmd.String("record"),
+		PlanFile: cmd.String("plan-file"),
 	})
 }
+
+func newPlanParser(cfg *config.Config) (terraform.Parser, error) {
+	switch cfg.Terraform.Plan.Format {
+	case config.PlanFormatJSON:
+		return terraform.NewJSONPlanParser(), nil
+	case config.PlanFormatText:
+		// Detect Terragrunt and HCP Terraform, but not JSON
+		parser := terraform.NewDetectingPlanParser(cfg.Terraform.Consolidated)
+		parser.JSON = nil
+		return parser, nil
+	case "":
+		return terraform.NewDetectingPlanParser(cfg.Terraform.Consolidated), nil
+	default:
+		return nil, fmt.Errorf("terraform.plan.format must be either %q or %q: %q", config.PlanFormatText, config.PlanFormatJSON, cfg.Terraform.Plan.Format)
+	}
+}
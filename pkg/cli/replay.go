@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/mercari/tfnotify/v1/pkg/controller"
+	"github.com/mercari/tfnotify/v1/pkg/terraform"
+	"github.com/urfave/cli/v3"
+)
+
+func cmdReplay(ctx context.Context, cmd *cli.Command) error {
+	logLevel := cmd.String("log-level")
+	setLogLevel(logLevel)
+
+	if cmd.Args().Len() != 1 {
+		return errors.New("the path of a record file must be specified")
+	}
+	record, err := controller.ReadRecord(cmd.Args().First())
+	if err != nil {
+		return err
+	}
+
+	cfg, err := newConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	if logLevel == "" {
+		logLevel = cfg.Log.Level
+		setLogLevel(logLevel)
+	}
+
+	if err := parseOpts(cmd, &cfg, os.Environ()); err != nil {
+		return err
+	}
+
+	t := &controller.Controller{
+		Config: cfg,
+	}
+	if record.Command == "apply" {
+		t.Parser = terraform.NewDetectingApplyParser(cfg.Terraform.Consolidated)
+		t.Template = terraform.NewApplyTemplate(cfg.Terraform.Apply.Template)
+		t.ParseErrorTemplate = terraform.NewApplyParseErrorTemplate(cfg.Terraform.Apply.WhenParseError.Template)
+	} else {
+		parser, err := newPlanParser(&cfg)
+		if err != nil {
+			return err
+		}
+		t.Parser = parser
+		t.Template = terraform.NewPlanTemplate(cfg.Terraform.Plan.Template)
+		t.ParseErrorTemplate = terraform.NewPlanParseErrorTemplate(cfg.Terraform.Plan.WhenParseError.Template)
+	}
+
+	return t.Replay(ctx, record)
+}
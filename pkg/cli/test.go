@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"context"
+	"os"
+
+	"github.com/mercari/tfnotify/v1/pkg/controller"
+	"github.com/mercari/tfnotify/v1/pkg/terraform"
+	"github.com/urfave/cli/v3"
+)
+
+func cmdTest(ctx context.Context, cmd *cli.Command) error {
+	logLevel := cmd.String("log-level")
+	setLogLevel(logLevel)
+
+	cfg, err := newConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	if logLevel == "" {
+		logLevel = cfg.Log.Level
+		setLogLevel(logLevel)
+	}
+
+	if err := parseOpts(cmd, &cfg, os.Environ()); err != nil {
+		return err
+	}
+
+	t := &controller.Controller{
+		Config:             cfg,
+		Parser:             terraform.NewTestParser(),
+		Template:           terraform.NewTestTemplate(cfg.Terraform.Test.Template),
+		ParseErrorTemplate: terraform.NewTestParseErrorTemplate(cfg.Terraform.Test.WhenParseError.Template),
+	}
+
+	args := cmd.Args()
+
+	return t.Test(ctx, controller.Command{
+		Cmd:  args.First(),
+		Args: args.Tail(),
+	})
+}
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"context"
+	"os"
+
+	"github.com/mercari/tfnotify/v1/pkg/controller"
+	"github.com/mercari/tfnotify/v1/pkg/terraform"
+	"github.com/urfave/cli/v3"
+)
+
+func cmdValidate(ctx context.Context, cmd *cli.Command) error {
+	logLevel := cmd.String("log-level")
+	setLogLevel(logLevel)
+
+	cfg, err := newConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	if logLevel == "" {
+		logLevel = cfg.Log.Level
+		setLogLevel(logLevel)
+	}
+
+	if err := parseOpts(cmd, &cfg, os.Environ()); err != nil {
+		return err
+	}
+
+	if cmd.IsSet("disable-label") {
+		cfg.Terraform.Validate.WhenValidateError.DisableLabel = cmd.Bool("disable-label")
+	}
+
+	t := &controller.Controller{
+		Config:             cfg,
+		Parser:             terraform.NewValidateParser(),
+		Template:           terraform.NewValidateTemplate(cfg.Terraform.Validate.Template),
+		ParseErrorTemplate: terraform.NewValidateParseErrorTemplate(cfg.Terraform.Validate.WhenParseError.Template),
+	}
+
+	args := cmd.Args()
+
+	return t.Validate(ctx, controller.Command{
+		Cmd:  args.First(),
+		Args: args.Tail(),
+	})
+}
@@ -53,7 +53,17 @@ func parseOpts(cmd *cli.Command, cfg *config.Config, envs []string) error { //no
 	}
 
 	if cmd.IsSet("patch") {
-		cfg.PlanPatch = cmd.Bool("patch")
+		// validate, fmt, and test have their own settings, so that plan_patch doesn't affect them
+		switch cmd.Name {
+		case "validate":
+			cfg.Terraform.Validate.Patch = cmd.Bool("patch")
+		case "fmt":
+			cfg.Terraform.Fmt.Patch = cmd.Bool("patch")
+		case "test":
+			cfg.Terraform.Test.Patch = cmd.Bool("patch")
+		default:
+			cfg.PlanPatch = cmd.Bool("patch")
+		}
 	}
 
 	if buildURL := cmd.String("build-url"); buildURL != "" {
@@ -87,6 +97,11 @@ func parseOpts(cmd *cli.Command, cfg *config.Config, envs []string) error { //no
 
 	if cmd.IsSet("disable-label") {
 		cfg.Terraform.Plan.DisableLabel = cmd.Bool("disable-label")
+		cfg.Terraform.Apply.DisableLabel = cmd.Bool("disable-label")
+	}
+
+	if infracost := cmd.String("infracost"); infracost != "" {
+		cfg.Cost.File = infracost
 	}
 
 	if cfg.GHEBaseURL == "" {
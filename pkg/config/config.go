@@ -27,6 +27,20 @@ type Config struct {
 	Output             string            `json:"-" yaml:"-"`
 	Masks              []*Mask           `json:"-" yaml:"-"`
 	AISummary          AISummary         `json:"ai_summary,omitempty" yaml:"ai_summary"`
+	Cost               Cost              `json:"cost,omitempty"`
+	Risk               *Risk             `json:"risk,omitempty"`
+	Checks             Checks            `json:"checks,omitempty"`
+}
+
+// Checks is a configuration to create GitHub check runs with the results of commands
+type Checks struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// Name is a template of the name of check runs, which is rendered with `.Vars` and `.Command`.
+	// It defaults to `tfnotify <command>` followed by the target
+	Name string `json:"name,omitempty"`
+	// WorkingDirectory is a template of the directory where Terraform runs, relative to the repository root.
+	// The paths of annotations are joined to it. It defaults to the current directory relative to GITHUB_WORKSPACE
+	WorkingDirectory string `json:"working_directory,omitempty" yaml:"working_directory"`
 }
 
 type Mask struct {
@@ -71,22 +85,39 @@ type Slack struct {
 	ApplyMessage       string `json:"apply_message,omitempty" yaml:"apply_message"`                 // Message template for apply notifications
 	PlanTitle          string `json:"plan_title,omitempty" yaml:"plan_title"`                       // Title template for plan notifications
 	PlanMessage        string `json:"plan_message,omitempty" yaml:"plan_message"`                   // Message template for plan notifications
-	NotifyOnPlanError  bool   `json:"notify_on_plan_error,omitempty" yaml:"notify_on_plan_error"`   // Send Slack notification on plan failures
+	NotifyOnPlanError  bool   `json:"notify_on_plan_error,omitempty" yaml:"notify_on_plan_error"`   // Send Slack notification on plan failures. It also enables notifications of validate, fmt, test, and drift
 	NotifyOnApplyError bool   `json:"notify_on_apply_error,omitempty" yaml:"notify_on_apply_error"` // Send Slack notification on apply failures
 	UseThreads         *bool  `json:"use_threads,omitempty" yaml:"use_threads"`                     // Send error details in a thread reply
+	// NotifyOnRiskLevel sends plan notifications whose risk level is equal to or higher than it
+	NotifyOnRiskLevel string `json:"notify_on_risk_level,omitempty" yaml:"notify_on_risk_level"`
+	// RiskChannelIDs is the channels per risk level. Plan notifications are posted to the channel of the risk level instead of SLACK_CHANNEL_ID
+	RiskChannelIDs map[string]string `json:"risk_channel_ids,omitempty" yaml:"risk_channel_ids"`
 }
 
 // Terraform represents terraform configurations
 type Terraform struct {
-	Plan         Plan  `json:"plan,omitempty"`
-	Apply        Apply `json:"apply,omitempty"`
-	UseRawOutput bool  `json:"use_raw_output,omitempty" yaml:"use_raw_output"`
-	Consolidated bool  `json:"consolidated,omitempty" yaml:"consolidated"`
+	Plan         Plan     `json:"plan,omitempty"`
+	Apply        Apply    `json:"apply,omitempty"`
+	Validate     Validate `json:"validate,omitempty"`
+	Fmt          Fmt      `json:"fmt,omitempty"`
+	Drift        Drift    `json:"drift,omitempty"`
+	Test         Test     `json:"test,omitempty"`
+	UseRawOutput bool     `json:"use_raw_output,omitempty" yaml:"use_raw_output"`
+	Consolidated bool     `json:"consolidated,omitempty" yaml:"consolidated"`
 }
 
+// Plan formats of the terraform plan result
+const (
+	// PlanFormatText is the human readable output of terraform plan
+	PlanFormatText = "text"
+	// PlanFormatJSON is the output of `terraform show -json`
+	PlanFormatJSON = "json"
+)
+
 // Plan is a terraform plan config
 type Plan struct {
 	Template            string              `json:"template,omitempty"`
+	Format              string              `json:"format,omitempty"` // "text", "json", or empty to detect the format automatically
 	WhenAddOrUpdateOnly WhenAddOrUpdateOnly `json:"when_add_or_update_only,omitempty" yaml:"when_add_or_update_only"`
 	WhenDestroy         WhenDestroy         `json:"when_destroy,omitempty" yaml:"when_destroy"`
 	WhenNoChanges       WhenNoChanges       `json:"when_no_changes,omitempty" yaml:"when_no_changes"`
@@ -94,6 +125,24 @@ type Plan struct {
 	WhenParseError      WhenParseError      `json:"when_parse_error,omitempty" yaml:"when_parse_error"`
 	DisableLabel        bool                `json:"disable_label,omitempty" yaml:"disable_label"`
 	IgnoreWarning       bool                `json:"ignore_warning,omitempty" yaml:"ignore_warning"`
+	// ProtectedResources is glob patterns of resource addresses which must not be deleted or replaced, e.g. `module.prod_db.*`
+	ProtectedResources   []string             `json:"protected_resources,omitempty" yaml:"protected_resources"`
+	WhenDestroyProtected WhenDestroyProtected `json:"when_destroy_protected,omitempty" yaml:"when_destroy_protected"`
+	// Labels is the labels added independently of the other labels when their conditions are satisfied
+	Labels []*PlanLabel `json:"labels,omitempty"`
+	// ModuleLabels is the labels added per Terragrunt module in consolidated mode.
+	// The name is a template rendered with `.Module` and `.Vars`, and `when` is evaluated against the changes of each module
+	ModuleLabels []*PlanLabel `json:"module_labels,omitempty" yaml:"module_labels"`
+}
+
+// PlanLabel is a label added when the condition When is satisfied, and removed otherwise
+type PlanLabel struct {
+	Name        string `json:"name"`
+	Color       string `json:"color,omitempty"`
+	Description string `json:"description,omitempty"`
+	// When is a template or an expression evaluated against the plan result, e.g. `gt .DestroyCount 0`.
+	// The label is always added if it is empty
+	When string `json:"when,omitempty"`
 }
 
 // WhenAddOrUpdateOnly is a configuration to notify the plan result contains new or updated in place resources
@@ -125,6 +174,15 @@ type WhenPlanError struct {
 	DisableLabel bool   `json:"disable_label,omitempty" yaml:"disable_label"`
 }
 
+// WhenDestroyProtected is a configuration to notify the plan result deletes or replaces protected resources
+type WhenDestroyProtected struct {
+	Label        string `json:"label,omitempty"`
+	Color        string `json:"label_color,omitempty" yaml:"label_color"`
+	DisableLabel bool   `json:"disable_label,omitempty" yaml:"disable_label"`
+	// Fail makes tfnotify exit with a non-zero code even if terraform plan succeeds
+	Fail bool `json:"fail,omitempty"`
+}
+
 // WhenParseError is a configuration to notify the plan result returns an error
 type WhenParseError struct {
 	Template string `json:"template,omitempty"`
@@ -134,6 +192,115 @@ type WhenParseError struct {
 type Apply struct {
 	Template       string         `json:"template,omitempty"`
 	WhenParseError WhenParseError `json:"when_parse_error,omitempty" yaml:"when_parse_error"`
+	WhenSuccess    WhenSuccess    `json:"when_success,omitempty" yaml:"when_success"`
+	WhenFailure    WhenFailure    `json:"when_failure,omitempty" yaml:"when_failure"`
+	// DisableLabel keeps the labels of the plan result after apply.
+	// By default, they are replaced with the label of the apply result.
+	DisableLabel bool `json:"disable_label,omitempty" yaml:"disable_label"`
+}
+
+// WhenSuccess is a configuration to replace the plan labels with a label when terraform apply succeeds
+type WhenSuccess struct {
+	Label        string `json:"label,omitempty"`
+	Color        string `json:"label_color,omitempty" yaml:"label_color"`
+	DisableLabel bool   `json:"disable_label,omitempty" yaml:"disable_label"`
+}
+
+// WhenFailure is a configuration to replace the plan labels with a label when terraform apply fails
+type WhenFailure struct {
+	Label        string `json:"label,omitempty"`
+	Color        string `json:"label_color,omitempty" yaml:"label_color"`
+	DisableLabel bool   `json:"disable_label,omitempty" yaml:"disable_label"`
+}
+
+// Validate is a terraform validate config
+type Validate struct {
+	Template          string            `json:"template,omitempty"`
+	WhenValidateError WhenValidateError `json:"when_validate_error,omitempty" yaml:"when_validate_error"`
+	WhenParseError    WhenParseError    `json:"when_parse_error,omitempty" yaml:"when_parse_error"`
+	// Patch updates the previous validate comment instead of posting a new comment
+	Patch bool `json:"patch,omitempty"`
+}
+
+// WhenValidateError is a configuration to add a label when the configuration is invalid
+type WhenValidateError struct {
+	Label        string `json:"label,omitempty"`
+	Color        string `json:"label_color,omitempty" yaml:"label_color"`
+	DisableLabel bool   `json:"disable_label,omitempty" yaml:"disable_label"`
+}
+
+// Fmt is a terraform fmt config
+type Fmt struct {
+	Template       string         `json:"template,omitempty"`
+	WhenParseError WhenParseError `json:"when_parse_error,omitempty" yaml:"when_parse_error"`
+	// Patch updates the previous fmt comment instead of posting a new comment
+	Patch bool `json:"patch,omitempty"`
+}
+
+// Drift is a drift detection config
+type Drift struct {
+	Template       string         `json:"template,omitempty"`
+	WhenParseError WhenParseError `json:"when_parse_error,omitempty" yaml:"when_parse_error"`
+	Issue          DriftIssue     `json:"issue,omitempty"`
+}
+
+// Test is a terraform test config
+type Test struct {
+	Template       string         `json:"template,omitempty"`
+	WhenParseError WhenParseError `json:"when_parse_error,omitempty" yaml:"when_parse_error"`
+	// Patch updates the previous test comment instead of posting a new comment
+	Patch bool `json:"patch,omitempty"`
+}
+
+// DriftIssue is a configuration of the GitHub issue to track drift
+type DriftIssue struct {
+	// Title is a template of the issue title. Only Vars are available
+	Title     string   `json:"title,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	Assignees []string `json:"assignees,omitempty"`
+}
+
+// Cost is a configuration of the cost estimated by Infracost
+type Cost struct {
+	// File is the path of the JSON output of `infracost breakdown` or `infracost diff`
+	File         string           `json:"file,omitempty"`
+	WhenIncrease WhenCostIncrease `json:"when_increase,omitempty" yaml:"when_increase"`
+}
+
+// WhenCostIncrease is a configuration to add a label when the monthly cost increases more than Threshold
+type WhenCostIncrease struct {
+	Threshold    float64 `json:"threshold,omitempty"`
+	Label        string  `json:"label,omitempty"`
+	Color        string  `json:"label_color,omitempty" yaml:"label_color"`
+	DisableLabel bool    `json:"disable_label,omitempty" yaml:"disable_label"`
+}
+
+// Risk is a configuration of the risk evaluation of plans.
+// The risk is evaluated only if it is configured, so `risk: {}` enables it with the default rules.
+type Risk struct {
+	Rules               []*RiskRule `json:"rules,omitempty"`
+	DisableDefaultRules bool        `json:"disable_default_rules,omitempty" yaml:"disable_default_rules"`
+	// Label is a template of the label added per risk level. .Vars and .Level are available
+	Label string `json:"label,omitempty"`
+	// LabelMinLevel is the lowest risk level to add the label. high by default
+	LabelMinLevel string            `json:"label_min_level,omitempty" yaml:"label_min_level"`
+	LabelColors   map[string]string `json:"label_colors,omitempty" yaml:"label_colors"`
+	DisableLabel  bool              `json:"disable_label,omitempty" yaml:"disable_label"`
+}
+
+// RiskRule is a rule to evaluate the risk of a plan. Empty conditions match any resources
+type RiskRule struct {
+	Name  string `json:"name"`
+	Level string `json:"level"` // low, medium, high, or critical
+	// ResourceTypes is glob patterns of resource types like `aws_iam_*`
+	ResourceTypes []string `json:"resource_types,omitempty" yaml:"resource_types"`
+	// Actions is the actions of resources: create, update, delete, replace, import, move, and forget
+	Actions []string `json:"actions,omitempty"`
+	// ModulePaths is glob patterns of module paths like `module.vpc` or Terragrunt modules like `prod/*`
+	ModulePaths []string `json:"module_paths,omitempty" yaml:"module_paths"`
+	// MinCount is the number of matched resources required to apply the rule
+	MinCount int    `json:"min_count,omitempty" yaml:"min_count"`
+	Reason   string `json:"reason,omitempty"`
 }
 
 // LoadFile binds the config file to Config structure
@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"maps"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"text/template"
 
 	"github.com/mercari/tfnotify/v1/pkg/config"
@@ -23,6 +26,8 @@ type Controller struct {
 	Template           *terraform.Template
 	ParseErrorTemplate *terraform.Template
 	AISummarizer       AISummarizer
+	// planFileParser merges the output of `terraform show -json` of the plan file. It is set by setPlanParser
+	planFileParser *terraform.PlanFileParser
 }
 
 type AISummarizer interface {
@@ -32,23 +37,156 @@ type AISummarizer interface {
 type Command struct {
 	Cmd  string
 	Args []string
+	// Input is the path of the file which has the output of a command which has already been run.
+	// "-" means the standard input. If Input is set, Cmd isn't run.
+	Input string
+	// ExitCode is the exit code of the command which printed Input
+	ExitCode int
+	// PlanFile is the path of the plan file created by `terraform plan -out`.
+	// If PlanFile is set, `show` and `show -json` are run with Cmd and Args, and Cmd defaults to "terraform".
+	PlanFile string
+	// Record is the path of the file to save the captured output to. It is replayed by `tfnotify replay`
+	Record string
 }
 
 func (c *Controller) renderTemplate(tpl string) (string, error) {
+	return c.renderTemplateWithData(tpl, map[string]any{
+		"Vars": c.Config.Vars,
+	})
+}
+
+func (c *Controller) renderTemplateWithData(tpl string, data map[string]any) (string, error) {
 	tmpl, err := template.New("_").Funcs(tmpl.TxtFuncMap()).Parse(tpl)
 	if err != nil {
 		return "", err
 	}
 	buf := &bytes.Buffer{}
-	if err := tmpl.Execute(buf, map[string]any{
-		"Vars": c.Config.Vars,
-	}); err != nil {
+	if err := tmpl.Execute(buf, data); err != nil {
 		return "", fmt.Errorf("render a label template: %w", err)
 	}
 	return buf.String(), nil
 }
 
 func (c *Controller) renderGitHubLabels() (github.ResultLabels, error) { //nolint:cyclop
+	target, ok := c.Config.Vars["target"]
+	if !ok {
+		target = ""
+	}
+
+	labels, err := c.renderResultLabels(target)
+	if err != nil {
+		return labels, err
+	}
+
+	if !c.Config.Terraform.Apply.DisableLabel {
+		if err := c.renderApplyLabels(&labels, target); err != nil {
+			return labels, err
+		}
+	}
+
+	if whenProtected := c.Config.Terraform.Plan.WhenDestroyProtected; len(c.Config.Terraform.Plan.ProtectedResources) != 0 && !whenProtected.DisableLabel {
+		labels.ProtectedLabelColor = whenProtected.Color
+		if labels.ProtectedLabelColor == "" {
+			labels.ProtectedLabelColor = "b60205" // dark red
+		}
+		if whenProtected.Label == "" {
+			if target == "" {
+				labels.ProtectedLabel = "destroy-protected"
+			} else {
+				labels.ProtectedLabel = target + "/destroy-protected"
+			}
+		} else {
+			protectedLabel, err := c.renderTemplate(whenProtected.Label)
+			if err != nil {
+				return labels, err
+			}
+			labels.ProtectedLabel = protectedLabel
+		}
+	}
+
+	if c.Config.Risk != nil && !c.Config.Risk.DisableLabel {
+		riskLabels, err := c.renderRiskLabels(target)
+		if err != nil {
+			return labels, err
+		}
+		labels.RiskLabels = riskLabels
+		labels.RiskLabelColors = map[string]string{
+			terraform.RiskLevelMedium:   "fbca04", // yellow
+			terraform.RiskLevelHigh:     "d93f0b", // red
+			terraform.RiskLevelCritical: "b60205", // dark red
+		}
+		maps.Copy(labels.RiskLabelColors, c.Config.Risk.LabelColors)
+	}
+
+	if whenIncrease := c.Config.Cost.WhenIncrease; c.Config.Cost.File != "" && !whenIncrease.DisableLabel {
+		labels.CostIncreaseThreshold = whenIncrease.Threshold
+		labels.CostIncreaseLabelColor = whenIncrease.Color
+		if labels.CostIncreaseLabelColor == "" {
+			labels.CostIncreaseLabelColor = "fbca04" // yellow
+		}
+		if whenIncrease.Label == "" {
+			if target == "" {
+				labels.CostIncreaseLabel = "cost-increase"
+			} else {
+				labels.CostIncreaseLabel = target + "/cost-increase"
+			}
+		} else {
+			costIncreaseLabel, err := c.renderTemplate(whenIncrease.Label)
+			if err != nil {
+				return labels, err
+			}
+			labels.CostIncreaseLabel = costIncreaseLabel
+		}
+	}
+
+	for _, label := range c.Config.Terraform.Plan.Labels {
+		name, err := c.renderTemplate(label.Name)
+		if err != nil {
+			return labels, err
+		}
+		if name == "" {
+			return labels, fmt.Errorf("the name of terraform.plan.labels is empty: %q", label.Name)
+		}
+		labels.ConditionalLabels = append(labels.ConditionalLabels, &github.ConditionalLabel{
+			Name:        name,
+			Color:       label.Color,
+			Description: label.Description,
+			When:        label.When,
+		})
+	}
+
+	for _, label := range c.Config.Terraform.Plan.ModuleLabels {
+		moduleLabel, err := terraform.NewModuleLabel(label.Name, c.Config.Vars)
+		if err != nil {
+			return labels, fmt.Errorf("terraform.plan.module_labels is invalid: %w", err)
+		}
+		labels.ModuleLabels = append(labels.ModuleLabels, &github.ModuleLabel{
+			Label:       moduleLabel,
+			Color:       label.Color,
+			Description: label.Description,
+			When:        label.When,
+		})
+	}
+
+	return labels, nil
+}
+
+// renderGitHubApplyLabels returns the labels updated by tfnotify apply, which are the labels of the plan result and the apply result.
+// The other labels of plans such as risk and module labels aren't rendered, because apply doesn't update them.
+func (c *Controller) renderGitHubApplyLabels() (github.ResultLabels, error) {
+	target := c.Config.Vars["target"]
+	labels, err := c.renderResultLabels(target)
+	if err != nil {
+		return labels, err
+	}
+	if err := c.renderApplyLabels(&labels, target); err != nil {
+		return labels, err
+	}
+	return labels, nil
+}
+
+// renderResultLabels returns the labels of the plan result such as add-or-update and destroy
+func (c *Controller) renderResultLabels(target string) (github.ResultLabels, error) { //nolint:cyclop
 	labels := github.ResultLabels{
 		AddOrUpdateLabelColor: c.Config.Terraform.Plan.WhenAddOrUpdateOnly.Color,
 		DestroyLabelColor:     c.Config.Terraform.Plan.WhenDestroy.Color,
@@ -56,11 +194,6 @@ func (c *Controller) renderGitHubLabels() (github.ResultLabels, error) { //nolin
 		PlanErrorLabelColor:   c.Config.Terraform.Plan.WhenPlanError.Color,
 	}
 
-	target, ok := c.Config.Vars["target"]
-	if !ok {
-		target = ""
-	}
-
 	if labels.AddOrUpdateLabelColor == "" {
 		labels.AddOrUpdateLabelColor = "1d76db" // blue
 	}
@@ -130,6 +263,148 @@ func (c *Controller) renderGitHubLabels() (github.ResultLabels, error) { //nolin
 	return labels, nil
 }
 
+// renderApplyLabels sets the labels of the apply result, which replace the labels of the plan result
+func (c *Controller) renderApplyLabels(labels *github.ResultLabels, target string) error {
+	whenSuccess := c.Config.Terraform.Apply.WhenSuccess
+	whenFailure := c.Config.Terraform.Apply.WhenFailure
+	labels.AppliedLabelColor = whenSuccess.Color
+	if labels.AppliedLabelColor == "" {
+		labels.AppliedLabelColor = "5319e7" // purple
+	}
+	labels.ApplyFailedLabelColor = whenFailure.Color
+	if labels.ApplyFailedLabelColor == "" {
+		labels.ApplyFailedLabelColor = "b60205" // dark red
+	}
+
+	if !whenSuccess.DisableLabel {
+		if whenSuccess.Label == "" {
+			if target == "" {
+				labels.AppliedLabel = "applied"
+			} else {
+				labels.AppliedLabel = target + "/applied"
+			}
+		} else {
+			appliedLabel, err := c.renderTemplate(whenSuccess.Label)
+			if err != nil {
+				return err
+			}
+			labels.AppliedLabel = appliedLabel
+		}
+	}
+
+	if !whenFailure.DisableLabel {
+		if whenFailure.Label == "" {
+			if target == "" {
+				labels.ApplyFailedLabel = "apply-failed"
+			} else {
+				labels.ApplyFailedLabel = target + "/apply-failed"
+			}
+		} else {
+			applyFailedLabel, err := c.renderTemplate(whenFailure.Label)
+			if err != nil {
+				return err
+			}
+			labels.ApplyFailedLabel = applyFailedLabel
+		}
+	}
+	return nil
+}
+
+// renderRiskLabels returns the labels per risk level.
+// The labels are rendered only for the levels equal to or higher than LabelMinLevel.
+func (c *Controller) renderRiskLabels(target string) (map[string]string, error) {
+	minLevel := c.Config.Risk.LabelMinLevel
+	if minLevel == "" {
+		minLevel = terraform.RiskLevelHigh
+	}
+	if err := terraform.ValidateRiskLevel(minLevel); err != nil {
+		return nil, fmt.Errorf("risk.label_min_level is invalid: %w", err)
+	}
+	labels := map[string]string{}
+	for _, level := range terraform.RiskLevels {
+		if terraform.CompareRiskLevel(level, minLevel) < 0 {
+			continue
+		}
+		if c.Config.Risk.Label == "" {
+			if target == "" {
+				labels[level] = "risk-" + level
+			} else {
+				labels[level] = target + "/risk-" + level
+			}
+			continue
+		}
+		label, err := c.renderTemplateWithData(c.Config.Risk.Label, map[string]any{
+			"Vars":  c.Config.Vars,
+			"Level": level,
+		})
+		if err != nil {
+			return nil, err
+		}
+		labels[level] = label
+	}
+	return labels, nil
+}
+
+// setPlanParser wraps c.Parser to merge the JSON plan, evaluate the risk, and find the protected resources.
+// The JSON plan is merged first, so that the risk and the protected resources are evaluated against the merged result.
+// The risk is evaluated only if it is configured.
+// The result is cached, so that the notifiers and the exit code share the result of one parse.
+func (c *Controller) setPlanParser() error {
+	c.planFileParser = &terraform.PlanFileParser{
+		Parser: c.Parser,
+	}
+	c.Parser = c.planFileParser
+	if c.Config.Risk != nil {
+		riskParser, err := c.newRiskParser()
+		if err != nil {
+			return err
+		}
+		c.Parser = riskParser
+	}
+	if len(c.Config.Terraform.Plan.ProtectedResources) != 0 {
+		if err := terraform.ValidateAddressPatterns(c.Config.Terraform.Plan.ProtectedResources); err != nil {
+			return fmt.Errorf("terraform.plan.protected_resources is invalid: %w", err)
+		}
+		c.Parser = &terraform.ProtectedResourceParser{
+			Parser:   c.Parser,
+			Patterns: c.Config.Terraform.Plan.ProtectedResources,
+		}
+	}
+	c.Parser = &terraform.CachedParser{
+		Parser: c.Parser,
+	}
+	return nil
+}
+
+// newRiskParser returns the parser which evaluates the risk of the result of c.Parser with the rules
+func (c *Controller) newRiskParser() (*terraform.RiskParser, error) {
+	rules := make([]*terraform.RiskRule, len(c.Config.Risk.Rules))
+	for i, rule := range c.Config.Risk.Rules {
+		if err := terraform.ValidateRiskLevel(rule.Level); err != nil {
+			return nil, fmt.Errorf("the level of the risk rule %s is invalid: %w", rule.Name, err)
+		}
+		if err := terraform.ValidateAddressPatterns(rule.ResourceTypes); err != nil {
+			return nil, fmt.Errorf("the resource types of the risk rule %s are invalid: %w", rule.Name, err)
+		}
+		if err := terraform.ValidateAddressPatterns(rule.ModulePaths); err != nil {
+			return nil, fmt.Errorf("the module paths of the risk rule %s are invalid: %w", rule.Name, err)
+		}
+		rules[i] = &terraform.RiskRule{
+			Name:          rule.Name,
+			Level:         rule.Level,
+			ResourceTypes: rule.ResourceTypes,
+			Actions:       rule.Actions,
+			ModulePaths:   rule.ModulePaths,
+			MinCount:      rule.MinCount,
+			Reason:        rule.Reason,
+		}
+	}
+	return &terraform.RiskParser{
+		Parser: c.Parser,
+		Engine: terraform.NewRiskEngine(rules, !c.Config.Risk.DisableDefaultRules),
+	}, nil
+}
+
 // parseBoolEnv returns the boolean value of the environment variable name.
 // If the variable is unset or empty, def is returned.
 func parseBoolEnv(name string, def bool) (bool, error) {
@@ -144,73 +419,203 @@ func parseBoolEnv(name string, def bool) (bool, error) {
 	return parsed, nil
 }
 
-func (c *Controller) getPlanNotifier(ctx context.Context) ([]notifier.Notifier, error) {
-	var notifiers []notifier.Notifier
+// getCheckNotifier returns the notifier creating GitHub check runs.
+// nil is returned if check runs are disabled or the output is written to a file.
+func (c *Controller) getCheckNotifier(ctx context.Context, command string) (notifier.Notifier, error) {
+	if !c.Config.Checks.Enabled || c.Config.Output != "" {
+		return nil, nil //nolint:nilnil
+	}
+	name := ""
+	if c.Config.Checks.Name != "" {
+		s, err := c.renderTemplateWithData(c.Config.Checks.Name, map[string]any{
+			"Vars":    c.Config.Vars,
+			"Command": command,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("render the name of check runs: %w", err)
+		}
+		name = s
+	}
+	workingDir, err := c.checkRunWorkingDirectory()
+	if err != nil {
+		return nil, err
+	}
+	client, err := github.NewClient(ctx, &github.Config{
+		BaseURL:         c.Config.GHEBaseURL,
+		GraphQLEndpoint: c.Config.GHEGraphQLEndpoint,
+		Owner:           c.Config.CI.Owner,
+		Repo:            c.Config.CI.Repo,
+		PR: github.PullRequest{
+			Revision: c.Config.CI.SHA,
+			Number:   c.Config.CI.PRNumber,
+		},
+		CI:                 c.Config.CI.Link,
+		Parser:             c.Parser,
+		UseRawOutput:       c.Config.Terraform.UseRawOutput,
+		Template:           c.Template,
+		ParseErrorTemplate: c.ParseErrorTemplate,
+		Vars:               c.Config.Vars,
+		Templates:          c.Config.Templates,
+		Masks:              c.Config.Masks,
+		CheckRunName:       name,
 
-	// Check if Slack is enabled and configured
-	if c.Config.Slack.Enabled {
-		token := os.Getenv("SLACK_BOT_TOKEN")
-		channelID := os.Getenv("SLACK_CHANNEL_ID")
-		botName := os.Getenv("SLACK_BOT_NAME")
-
-		if token != "" && channelID != "" {
-			// Allow overriding titles and messages via environment variables
-			planTitle := c.Config.Slack.PlanTitle
-			if envTitle := os.Getenv("SLACK_PLAN_TITLE"); envTitle != "" {
-				planTitle = envTitle
-			}
+		CheckRunWorkingDirectory: workingDir,
+		FailOnProtectedResources: c.Config.Terraform.Plan.WhenDestroyProtected.Fail,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return client.Check, nil
+}
 
-			planMessage := c.Config.Slack.PlanMessage
-			if envMessage := os.Getenv("SLACK_PLAN_MESSAGE"); envMessage != "" {
-				planMessage = envMessage
-			}
+// checkRunWorkingDirectory returns the directory where Terraform runs, relative to the repository root.
+// If checks.working_directory isn't set, the current directory relative to GITHUB_WORKSPACE is returned.
+// An empty string is returned if the directory can't be detected.
+func (c *Controller) checkRunWorkingDirectory() (string, error) {
+	if c.Config.Checks.WorkingDirectory != "" {
+		dir, err := c.renderTemplate(c.Config.Checks.WorkingDirectory)
+		if err != nil {
+			return "", fmt.Errorf("render the working directory of check runs: %w", err)
+		}
+		return dir, nil
+	}
+	root := os.Getenv("GITHUB_WORKSPACE")
+	if root == "" {
+		return "", nil
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("get the current directory: %w", err)
+	}
+	dir, err := filepath.Rel(root, wd)
+	if err != nil || dir == ".." || strings.HasPrefix(dir, ".."+string(filepath.Separator)) {
+		// the current directory is outside the repository
+		return "", nil //nolint:nilerr
+	}
+	return filepath.ToSlash(dir), nil
+}
 
-			// Threads are enabled by default; config and env var can override
-			useThreads := true
-			if c.Config.Slack.UseThreads != nil {
-				useThreads = *c.Config.Slack.UseThreads
-			}
-			var err error
-			useThreads, err = parseBoolEnv("SLACK_USE_THREADS", useThreads)
-			if err != nil {
-				return nil, err
-			}
+// validateSlackRiskLevels returns an error if notify_on_risk_level or a key of risk_channel_ids isn't a known risk level.
+// Otherwise an unknown level would be treated as lower than any level and every plan would be notified.
+func validateSlackRiskLevels(cfg config.Slack) error {
+	if cfg.NotifyOnRiskLevel != "" {
+		if err := terraform.ValidateRiskLevel(cfg.NotifyOnRiskLevel); err != nil {
+			return fmt.Errorf("slack.notify_on_risk_level is invalid: %w", err)
+		}
+	}
+	for level := range cfg.RiskChannelIDs {
+		if err := terraform.ValidateRiskLevel(level); err != nil {
+			return fmt.Errorf("slack.risk_channel_ids is invalid: %w", err)
+		}
+	}
+	return nil
+}
 
-			notifyOnPlanError, err := parseBoolEnv("SLACK_NOTIFY_ON_PLAN_ERROR", c.Config.Slack.NotifyOnPlanError)
-			if err != nil {
-				return nil, err
-			}
+// getSlackNotifier returns the Slack notifier.
+// nil is returned if Slack is disabled or the token or the channel isn't set.
+func (c *Controller) getSlackNotifier() (notifier.Notifier, error) {
+	if !c.Config.Slack.Enabled {
+		return nil, nil //nolint:nilnil
+	}
+	token := os.Getenv("SLACK_BOT_TOKEN")
+	channelID := os.Getenv("SLACK_CHANNEL_ID")
+	botName := os.Getenv("SLACK_BOT_NAME")
+	if token == "" || channelID == "" {
+		return nil, nil //nolint:nilnil
+	}
 
-			notifyOnApplyError, err := parseBoolEnv("SLACK_NOTIFY_ON_APPLY_ERROR", c.Config.Slack.NotifyOnApplyError)
-			if err != nil {
-				return nil, err
-			}
+	// Allow overriding titles and messages via environment variables
+	planTitle := c.Config.Slack.PlanTitle
+	if envTitle := os.Getenv("SLACK_PLAN_TITLE"); envTitle != "" {
+		planTitle = envTitle
+	}
 
-			client, err := slack.NewClient(&slack.Config{
-				Token:              token,
-				ChannelID:          channelID,
-				BotName:            botName,
-				CI:                 c.Config.CI,
-				Parser:             c.Parser,
-				Template:           c.Template,
-				ParseErrorTemplate: c.ParseErrorTemplate,
-				Terraform:          c.Config.Terraform,
-				Vars:               c.Config.Vars,
-				Templates:          c.Config.Templates,
-				UseRawOutput:       c.Config.Terraform.UseRawOutput,
-				Title:              c.Config.Slack.Title,
-				Message:            c.Config.Slack.Message,
-				PlanTitle:          planTitle,
-				PlanMessage:        planMessage,
-				NotifyOnPlanError:  notifyOnPlanError,
-				NotifyOnApplyError: notifyOnApplyError,
-				UseThreads:         useThreads,
-			})
-			if err != nil {
-				return nil, fmt.Errorf("failed to create Slack client: %w", err)
-			}
-			notifiers = append(notifiers, client.Notify())
-		}
+	planMessage := c.Config.Slack.PlanMessage
+	if envMessage := os.Getenv("SLACK_PLAN_MESSAGE"); envMessage != "" {
+		planMessage = envMessage
+	}
+
+	applyTitle := c.Config.Slack.ApplyTitle
+	if envTitle := os.Getenv("SLACK_APPLY_TITLE"); envTitle != "" {
+		applyTitle = envTitle
+	}
+
+	applyMessage := c.Config.Slack.ApplyMessage
+	if envMessage := os.Getenv("SLACK_APPLY_MESSAGE"); envMessage != "" {
+		applyMessage = envMessage
+	}
+
+	// Threads are enabled by default; config and env var can override
+	useThreads := true
+	if c.Config.Slack.UseThreads != nil {
+		useThreads = *c.Config.Slack.UseThreads
+	}
+	useThreads, err := parseBoolEnv("SLACK_USE_THREADS", useThreads)
+	if err != nil {
+		return nil, err
+	}
+
+	notifyOnPlanError, err := parseBoolEnv("SLACK_NOTIFY_ON_PLAN_ERROR", c.Config.Slack.NotifyOnPlanError)
+	if err != nil {
+		return nil, err
+	}
+
+	notifyOnApplyError, err := parseBoolEnv("SLACK_NOTIFY_ON_APPLY_ERROR", c.Config.Slack.NotifyOnApplyError)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateSlackRiskLevels(c.Config.Slack); err != nil {
+		return nil, err
+	}
+
+	client, err := slack.NewClient(&slack.Config{
+		Token:              token,
+		ChannelID:          channelID,
+		BotName:            botName,
+		CI:                 c.Config.CI,
+		Parser:             c.Parser,
+		Template:           c.Template,
+		ParseErrorTemplate: c.ParseErrorTemplate,
+		Terraform:          c.Config.Terraform,
+		Vars:               c.Config.Vars,
+		Templates:          c.Config.Templates,
+		UseRawOutput:       c.Config.Terraform.UseRawOutput,
+		Title:              c.Config.Slack.Title,
+		Message:            c.Config.Slack.Message,
+		PlanTitle:          planTitle,
+		PlanMessage:        planMessage,
+		ApplyTitle:         applyTitle,
+		ApplyMessage:       applyMessage,
+		NotifyOnPlanError:  notifyOnPlanError,
+		NotifyOnApplyError: notifyOnApplyError,
+		UseThreads:         useThreads,
+		NotifyOnRiskLevel:  c.Config.Slack.NotifyOnRiskLevel,
+		RiskChannelIDs:     c.Config.Slack.RiskChannelIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Slack client: %w", err)
+	}
+	return client.Notify(), nil
+}
+
+func (c *Controller) getPlanNotifier(ctx context.Context) ([]notifier.Notifier, error) {
+	var notifiers []notifier.Notifier
+
+	slackNotifier, err := c.getSlackNotifier()
+	if err != nil {
+		return nil, err
+	}
+	if slackNotifier != nil {
+		notifiers = append(notifiers, slackNotifier)
+	}
+
+	checkNotifier, err := c.getCheckNotifier(ctx, "plan")
+	if err != nil {
+		return nil, err
+	}
+	if checkNotifier != nil {
+		notifiers = append(notifiers, checkNotifier)
 	}
 
 	labels := github.ResultLabels{}
@@ -283,70 +688,20 @@ func (c *Controller) getPlanNotifier(ctx context.Context) ([]notifier.Notifier,
 func (c *Controller) getApplyNotifier(ctx context.Context) ([]notifier.Notifier, error) {
 	var notifiers []notifier.Notifier
 
-	// Check if Slack is enabled and configured
-	if c.Config.Slack.Enabled {
-		token := os.Getenv("SLACK_BOT_TOKEN")
-		channelID := os.Getenv("SLACK_CHANNEL_ID")
-		botName := os.Getenv("SLACK_BOT_NAME")
-
-		if token != "" && channelID != "" {
-			// Allow overriding titles and messages via environment variables
-			applyTitle := c.Config.Slack.ApplyTitle
-			if envTitle := os.Getenv("SLACK_APPLY_TITLE"); envTitle != "" {
-				applyTitle = envTitle
-			}
-
-			applyMessage := c.Config.Slack.ApplyMessage
-			if envMessage := os.Getenv("SLACK_APPLY_MESSAGE"); envMessage != "" {
-				applyMessage = envMessage
-			}
-
-			// Threads are enabled by default; config and env var can override
-			useThreads := true
-			if c.Config.Slack.UseThreads != nil {
-				useThreads = *c.Config.Slack.UseThreads
-			}
-			var err error
-			useThreads, err = parseBoolEnv("SLACK_USE_THREADS", useThreads)
-			if err != nil {
-				return nil, err
-			}
-
-			notifyOnPlanError, err := parseBoolEnv("SLACK_NOTIFY_ON_PLAN_ERROR", c.Config.Slack.NotifyOnPlanError)
-			if err != nil {
-				return nil, err
-			}
-
-			notifyOnApplyError, err := parseBoolEnv("SLACK_NOTIFY_ON_APPLY_ERROR", c.Config.Slack.NotifyOnApplyError)
-			if err != nil {
-				return nil, err
-			}
+	slackNotifier, err := c.getSlackNotifier()
+	if err != nil {
+		return nil, err
+	}
+	if slackNotifier != nil {
+		notifiers = append(notifiers, slackNotifier)
+	}
 
-			client, err := slack.NewClient(&slack.Config{
-				Token:              token,
-				ChannelID:          channelID,
-				BotName:            botName,
-				CI:                 c.Config.CI,
-				Parser:             c.Parser,
-				Template:           c.Template,
-				ParseErrorTemplate: c.ParseErrorTemplate,
-				Terraform:          c.Config.Terraform,
-				Vars:               c.Config.Vars,
-				Templates:          c.Config.Templates,
-				UseRawOutput:       c.Config.Terraform.UseRawOutput,
-				Title:              c.Config.Slack.Title,
-				Message:            c.Config.Slack.Message,
-				ApplyTitle:         applyTitle,
-				ApplyMessage:       applyMessage,
-				NotifyOnPlanError:  notifyOnPlanError,
-				NotifyOnApplyError: notifyOnApplyError,
-				UseThreads:         useThreads,
-			})
-			if err != nil {
-				return nil, fmt.Errorf("failed to create Slack client: %w", err)
-			}
-			notifiers = append(notifiers, client.Notify())
-		}
+	checkNotifier, err := c.getCheckNotifier(ctx, "apply")
+	if err != nil {
+		return nil, err
+	}
+	if checkNotifier != nil {
+		notifiers = append(notifiers, checkNotifier)
 	}
 
 	if c.Config.Output != "" {
@@ -370,6 +725,15 @@ func (c *Controller) getApplyNotifier(ctx context.Context) ([]notifier.Notifier,
 		notifiers = append(notifiers, client.Notify)
 		return notifiers, nil
 	}
+	labels := github.ResultLabels{}
+	if !c.Config.Terraform.Apply.DisableLabel {
+		// The labels of the plan result are rendered as well to replace them
+		a, err := c.renderGitHubApplyLabels()
+		if err != nil {
+			return nil, err
+		}
+		labels = a
+	}
 	client, err := github.NewClient(ctx, &github.Config{
 		BaseURL:         c.Config.GHEBaseURL,
 		GraphQLEndpoint: c.Config.GHEGraphQLEndpoint,
@@ -384,6 +748,7 @@ func (c *Controller) getApplyNotifier(ctx context.Context) ([]notifier.Notifier,
 		UseRawOutput:       c.Config.Terraform.UseRawOutput,
 		Template:           c.Template,
 		ParseErrorTemplate: c.ParseErrorTemplate,
+		ResultLabels:       labels,
 		Vars:               c.Config.Vars,
 		EmbeddedVarNames:   c.Config.EmbeddedVarNames,
 		Templates:          c.Config.Templates,
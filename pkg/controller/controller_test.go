@@ -1,7 +1,14 @@
 package controller
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mercari/tfnotify/v1/pkg/config"
+	"github.com/mercari/tfnotify/v1/pkg/notifier"
+	"github.com/mercari/tfnotify/v1/pkg/terraform"
 )
 
 func TestParseBoolEnv(t *testing.T) { //nolint:paralleltest
@@ -44,3 +51,258 @@ func TestParseBoolEnv(t *testing.T) { //nolint:paralleltest
 		})
 	}
 }
+
+func TestValidateInput(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		command Command
+		wantErr bool
+	}{
+		{name: "command", command: Command{Cmd: "terraform"}},
+		{name: "input", command: Command{Input: "-"}},
+		{name: "both", command: Command{Cmd: "terraform", Input: "-"}, wantErr: true},
+		{name: "neither", command: Command{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateInput(tt.command)
+			if tt.wantErr && err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestReadInput(t *testing.T) {
+	t.Parallel()
+	p := filepath.Join(t.TempDir(), "plan.log")
+	if err := os.WriteFile(p, []byte("\x1b[1mPlan:\x1b[0m 1 to add, 0 to change, 0 to destroy.\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ctrl := &Controller{Config: config.Config{}}
+	param, err := ctrl.readInput(Command{Input: p, ExitCode: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "Plan: 1 to add, 0 to change, 0 to destroy.\n"; param.CombinedOutput != want {
+		t.Errorf("CombinedOutput = %q, want %q", param.CombinedOutput, want)
+	}
+	if param.ExitCode != 2 {
+		t.Errorf("ExitCode = %d, want 2", param.ExitCode)
+	}
+	if _, err := ctrl.readInput(Command{Input: filepath.Join(t.TempDir(), "missing")}); err == nil {
+		t.Error("expected an error for a missing file, got nil")
+	}
+}
+
+func TestRecord(t *testing.T) {
+	t.Parallel()
+	p := filepath.Join(t.TempDir(), "run.tfnotify.json")
+	ctrl := &Controller{Config: config.Config{
+		CI:    config.CI{Name: "github-actions", SHA: "abc", PRNumber: 1},
+		Vars:  map[string]string{"target": "foo"},
+		Masks: []*config.Mask{{Type: "equal", Value: "secret"}},
+	}}
+	if err := ctrl.writeRecord(p, "plan", &notifier.ParamExec{
+		CombinedOutput: "password = secret",
+		ExitCode:       2,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	record, err := ReadRecord(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(record, &Record{
+		Command: "plan",
+		Param: &notifier.ParamExec{
+			CombinedOutput: "password = ***",
+			ExitCode:       2,
+		},
+		CI:   ctrl.Config.CI,
+		Vars: map[string]string{"target": "foo"},
+	}); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestRenderRiskLabels(t *testing.T) {
+	t.Parallel()
+	ctrl := &Controller{Config: config.Config{
+		Vars: map[string]string{"target": "foo"},
+		Risk: &config.Risk{},
+	}}
+	labels, err := ctrl.renderRiskLabels("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(labels, map[string]string{"high": "foo/risk-high", "critical": "foo/risk-critical"}); diff != "" {
+		t.Error(diff)
+	}
+
+	ctrl.Config.Risk = &config.Risk{
+		Label:         "{{.Vars.target}}:{{.Level}}",
+		LabelMinLevel: "medium",
+	}
+	labels, err = ctrl.renderRiskLabels("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(labels, map[string]string{"medium": "foo:medium", "high": "foo:high", "critical": "foo:critical"}); diff != "" {
+		t.Error(diff)
+	}
+
+	ctrl.Config.Risk.LabelMinLevel = "severe"
+	if _, err := ctrl.renderRiskLabels("foo"); err == nil {
+		t.Error("an invalid level must be rejected")
+	}
+}
+
+func TestRenderGitHubApplyLabels(t *testing.T) {
+	t.Parallel()
+	ctrl := &Controller{Config: config.Config{
+		Vars: map[string]string{"target": "foo"},
+		Terraform: config.Terraform{
+			Plan: config.Plan{
+				// labels which apply doesn't update must not be rendered
+				Labels:       []*config.PlanLabel{{Name: "{{.Vars.target"}},
+				ModuleLabels: []*config.PlanLabel{{Name: "destroy"}},
+			},
+		},
+		Risk: &config.Risk{LabelMinLevel: "severe"},
+	}}
+	labels, err := ctrl.renderGitHubApplyLabels()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if labels.DestroyLabel != "foo/destroy" {
+		t.Errorf("DestroyLabel = %q, want %q", labels.DestroyLabel, "foo/destroy")
+	}
+	if labels.AppliedLabel != "foo/applied" || labels.ApplyFailedLabel != "foo/apply-failed" {
+		t.Errorf("AppliedLabel = %q, ApplyFailedLabel = %q", labels.AppliedLabel, labels.ApplyFailedLabel)
+	}
+	if labels.ConditionalLabels != nil || labels.ModuleLabels != nil || labels.RiskLabels != nil {
+		t.Error("the labels of plans other than the result labels must not be rendered")
+	}
+}
+
+func TestValidateSlackRiskLevels(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		cfg     config.Slack
+		wantErr bool
+	}{
+		{name: "empty"},
+		{
+			name: "valid",
+			cfg: config.Slack{
+				NotifyOnRiskLevel: "high",
+				RiskChannelIDs:    map[string]string{"critical": "C0123"},
+			},
+		},
+		{name: "invalid notify_on_risk_level", cfg: config.Slack{NotifyOnRiskLevel: "High"}, wantErr: true},
+		{name: "invalid risk_channel_ids", cfg: config.Slack{RiskChannelIDs: map[string]string{"hgh": "C0123"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := validateSlackRiskLevels(tt.cfg); (err != nil) != tt.wantErr {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestPlanExitCode(t *testing.T) {
+	t.Parallel()
+	output := "  # aws_kms_key.main will be destroyed\n\nPlan: 0 to add, 0 to change, 1 to destroy.\n"
+	tests := []struct {
+		name     string
+		fail     bool
+		exitCode int
+		output   string
+		planJSON string
+		want     int
+		wantErr  bool
+	}{
+		{name: "fail", fail: true, exitCode: 0, want: 1, wantErr: true},
+		{
+			name:     "fail with JSON plan",
+			fail:     true,
+			output:   "unparsable output",
+			planJSON: `{"format_version":"1.2","resource_changes":[{"address":"aws_kms_key.main","mode":"managed","type":"aws_kms_key","name":"main","change":{"actions":["delete"]}}]}`,
+			want:     1,
+			wantErr:  true,
+		},
+		{name: "fail with detailed exit code", fail: true, exitCode: 2, want: 1, wantErr: true},
+		{name: "not fail", exitCode: 2, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := &Controller{
+				Config: config.Config{
+					Terraform: config.Terraform{
+						Plan: config.Plan{
+							ProtectedResources: []string{"aws_kms_key.*"},
+							WhenDestroyProtected: config.WhenDestroyProtected{
+								Fail: tt.fail,
+							},
+						},
+					},
+				},
+				Parser: terraform.NewPlanParser(),
+			}
+			if err := ctrl.setPlanParser(); err != nil {
+				t.Fatal(err)
+			}
+			ctrl.planFileParser.PlanJSON = tt.planJSON
+			combinedOutput := output
+			if tt.output != "" {
+				combinedOutput = tt.output
+			}
+			code, err := ctrl.planExitCode(tt.exitCode, ctrl.Parser.Parse(combinedOutput), nil)
+			if code != tt.want {
+				t.Errorf("exit code = %d, want %d", code, tt.want)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckRunWorkingDirectory(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "envs", "prod")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+	t.Setenv("GITHUB_WORKSPACE", root)
+	ctrl := &Controller{Config: config.Config{
+		Vars: map[string]string{"target": "prod"},
+	}}
+	wd, err := ctrl.checkRunWorkingDirectory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wd != "envs/prod" {
+		t.Errorf("the current directory must be detected: %s", wd)
+	}
+
+	ctrl.Config.Checks.WorkingDirectory = "stacks/{{.Vars.target}}"
+	wd, err = ctrl.checkRunWorkingDirectory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wd != "stacks/prod" {
+		t.Errorf("checks.working_directory must be rendered: %s", wd)
+	}
+}
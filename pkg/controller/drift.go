@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/mercari/tfnotify/v1/pkg/notifier"
+	"github.com/mercari/tfnotify/v1/pkg/notifier/github"
+)
+
+// Drift sends the notification with notifier
+func (c *Controller) Drift(ctx context.Context, command Command) error {
+	return c.runSubcommand(ctx, command, &subcommand{
+		name:   "drift",
+		notify: notifier.Notifier.Drift,
+		setGitHubConfig: func(cfg *github.Config) error {
+			title, err := c.renderDriftIssueTitle()
+			if err != nil {
+				return err
+			}
+			cfg.DriftIssue = github.DriftIssue{
+				Title:     title,
+				Labels:    c.Config.Terraform.Drift.Issue.Labels,
+				Assignees: c.Config.Terraform.Drift.Issue.Assignees,
+			}
+			return nil
+		},
+	})
+}
+
+func (c *Controller) renderDriftIssueTitle() (string, error) {
+	title := c.Config.Terraform.Drift.Issue.Title
+	if title == "" {
+		if target := c.Config.Vars["target"]; target != "" {
+			return "Drift detected (" + target + ")", nil
+		}
+		return "Drift detected", nil
+	}
+	return c.renderTemplate(title)
+}
@@ -0,0 +1,16 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/mercari/tfnotify/v1/pkg/notifier"
+)
+
+// Fmt sends the notification with notifier
+func (c *Controller) Fmt(ctx context.Context, command Command) error {
+	return c.runSubcommand(ctx, command, &subcommand{
+		name:   "fmt",
+		patch:  c.Config.Terraform.Fmt.Patch,
+		notify: notifier.Notifier.Fmt,
+	})
+}
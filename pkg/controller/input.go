@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+
+	"github.com/mattn/go-colorable"
+	"github.com/mercari/tfnotify/v1/pkg/mask"
+	"github.com/mercari/tfnotify/v1/pkg/notifier"
+)
+
+// validateInput validates a command of plan and apply, which accept either a command or Input
+func validateInput(command Command) error {
+	if command.Input != "" && command.Cmd != "" {
+		return errors.New("--input and a command can't be specified at the same time")
+	}
+	if command.Input != "" && command.PlanFile != "" {
+		return errors.New("--input and --plan-file can't be specified at the same time")
+	}
+	if command.Input == "" && command.Cmd == "" && command.PlanFile == "" {
+		return errors.New("no command specified")
+	}
+	return nil
+}
+
+// execute runs the command, or reads the output of the command from Input if Input is set.
+// If PlanFile is set, the plan file is shown instead.
+func (c *Controller) execute(ctx context.Context, command Command) (*notifier.ParamExec, error) {
+	if command.Input != "" {
+		return c.readInput(command)
+	}
+	if command.PlanFile != "" {
+		return c.showPlanFile(ctx, command)
+	}
+	return c.runCommand(ctx, command), nil
+}
+
+// readInput reads the output of a command which has already been run from a file or the standard input.
+// The output is also written to the standard output with masks like runCommand.
+// Stdout and Stderr can't be distinguished, so the whole output is set to Stdout and CombinedOutput.
+func (c *Controller) readInput(command Command) (*notifier.ParamExec, error) {
+	var r io.Reader = os.Stdin
+	if command.Input != "-" {
+		f, err := os.Open(command.Input)
+		if err != nil {
+			return nil, fmt.Errorf("open the input file: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+	output := &bytes.Buffer{}
+	if _, err := io.Copy(io.MultiWriter(mask.NewWriter(os.Stdout, c.Config.Masks), colorable.NewNonColorable(output)), r); err != nil {
+		return nil, fmt.Errorf("read the input: %w", err)
+	}
+	return &notifier.ParamExec{
+		Stdout:         output.String(),
+		CombinedOutput: output.String(),
+		CIName:         c.Config.CI.Name,
+		ExitCode:       command.ExitCode,
+		AISummarizer:   c.AISummarizer,
+	}, nil
+}
+
+// showPlanFile runs `show` and `show -json` for the plan file.
+// The output of `show` is notified as if `plan` had just been run, and the output of `show -json` is set to PlanJSON.
+// Args are passed before `show`, so `-- terraform -chdir=dir` works.
+func (c *Controller) showPlanFile(ctx context.Context, command Command) (*notifier.ParamExec, error) {
+	checksum, err := fileChecksum(command.PlanFile)
+	if err != nil {
+		return nil, err
+	}
+	if command.Cmd == "" {
+		command.Cmd = "terraform"
+	}
+	param := c.runCommand(ctx, Command{
+		Cmd:  command.Cmd,
+		Args: append(slices.Clone(command.Args), "show", command.PlanFile),
+	})
+	param.PlanFileChecksum = checksum
+	if param.ExitCode != 0 {
+		return param, nil
+	}
+
+	cmd := exec.CommandContext(ctx, command.Cmd, append(slices.Clone(command.Args), "show", "-json", command.PlanFile)...) //nolint:gosec
+	cmd.Env = append(os.Environ(), "COMMIT_SHA="+c.Config.CI.SHA)
+	stdout := &bytes.Buffer{}
+	cmd.Stdout = stdout
+	cmd.Stderr = mask.NewWriter(os.Stderr, c.Config.Masks)
+	setCancel(cmd)
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("show the plan file as JSON: %w", err)
+	}
+	param.PlanJSON = stdout.String()
+	return param, nil
+}
+
+// fileChecksum returns the SHA256 checksum of the file
+func fileChecksum(p string) (string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", fmt.Errorf("open the plan file: %w", err)
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("read the plan file: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"os/exec"
+	"strings"
 	"time"
 
 	"github.com/mattn/go-colorable"
@@ -14,12 +16,13 @@ import (
 	"github.com/mercari/tfnotify/v1/pkg/mask"
 	"github.com/mercari/tfnotify/v1/pkg/notifier"
 	"github.com/mercari/tfnotify/v1/pkg/platform"
+	"github.com/mercari/tfnotify/v1/pkg/terraform"
 )
 
 // Plan sends the notification with notifier
 func (c *Controller) Plan(ctx context.Context, command Command) error {
-	if command.Cmd == "" {
-		return errors.New("no command specified")
+	if err := validateInput(command); err != nil {
+		return err
 	}
 	if err := platform.Complement(&c.Config); err != nil {
 		return err
@@ -34,6 +37,10 @@ func (c *Controller) Plan(ctx context.Context, command Command) error {
 	}
 	c.Config.Vars["COMMIT_SHA"] = c.Config.CI.SHA
 
+	if err := c.setPlanParser(); err != nil {
+		return err
+	}
+
 	ntf, err := c.getPlanNotifier(ctx)
 	if err != nil {
 		return err
@@ -43,6 +50,53 @@ func (c *Controller) Plan(ctx context.Context, command Command) error {
 	}
 
 	// Execute command once
+	param, err := c.execute(ctx, command)
+	if err != nil {
+		return err
+	}
+	c.planFileParser.PlanJSON = param.PlanJSON
+
+	// Iterate over notifiers
+	var errs error
+	if c.Config.Cost.File != "" {
+		cost, err := terraform.ReadInfracostFile(c.Config.Cost.File)
+		if err != nil {
+			errs = errors.Join(errs, err)
+		}
+		param.Cost = cost
+	}
+	if command.Record != "" {
+		if err := c.writeRecord(command.Record, "plan", param); err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+	for _, n := range ntf {
+		if err := n.Plan(ctx, param); err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	// The result parsed by the notifiers is cached, so the output isn't parsed again
+	return apperr.NewExitError(c.planExitCode(param.ExitCode, c.Parser.Parse(param.CombinedOutput), errs))
+}
+
+// planExitCode returns the exit code and the error of plan.
+// If terraform.plan.when_destroy_protected.fail is true and the plan deletes or replaces protected resources,
+// the exit code is non-zero even if terraform plan succeeds.
+func (c *Controller) planExitCode(exitCode int, result terraform.ParseResult, errs error) (int, error) {
+	if !c.Config.Terraform.Plan.WhenDestroyProtected.Fail || exitCode == apperr.ExitCodeError {
+		return exitCode, errs
+	}
+	protected := result.ProtectedResources
+	if len(protected) == 0 {
+		return exitCode, errs
+	}
+	return apperr.ExitCodeError, errors.Join(errs, fmt.Errorf("the plan deletes or replaces protected resources: %s", strings.Join(protected, ", ")))
+}
+
+// runCommand executes the command once and captures its outputs.
+// The outputs are also written to the standard output and the standard error with masks.
+func (c *Controller) runCommand(ctx context.Context, command Command) *notifier.ParamExec {
 	cmd := exec.CommandContext(ctx, command.Cmd, command.Args...) //nolint:gosec
 	cmd.Stdin = os.Stdin
 	cmd.Env = append(os.Environ(), "COMMIT_SHA="+c.Config.CI.SHA)
@@ -57,25 +111,14 @@ func (c *Controller) Plan(ctx context.Context, command Command) error {
 	setCancel(cmd)
 	_ = cmd.Run()
 
-	// Iterate over notifiers
-	var errs error
-	for _, n := range ntf {
-		if err := n.Plan(ctx, &notifier.ParamExec{
-			Stdout:         stdout.String(),
-			Stderr:         stderr.String(),
-			CombinedOutput: combinedOutput.String(),
-			CIName:         c.Config.CI.Name,
-			ExitCode:       cmd.ProcessState.ExitCode(),
-			AISummarizer:   c.AISummarizer,
-		}); err != nil {
-			errs = errors.Join(errs, err)
-		}
-	}
-
-	if errs != nil {
-		return apperr.NewExitError(cmd.ProcessState.ExitCode(), errs)
+	return &notifier.ParamExec{
+		Stdout:         stdout.String(),
+		Stderr:         stderr.String(),
+		CombinedOutput: combinedOutput.String(),
+		CIName:         c.Config.CI.Name,
+		ExitCode:       cmd.ProcessState.ExitCode(),
+		AISummarizer:   c.AISummarizer,
 	}
-	return apperr.NewExitError(cmd.ProcessState.ExitCode(), nil)
 }
 
 const waitDelay = 1000 * time.Hour
@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+
+	"github.com/mercari/tfnotify/v1/pkg/apperr"
+	"github.com/mercari/tfnotify/v1/pkg/config"
+	"github.com/mercari/tfnotify/v1/pkg/mask"
+	"github.com/mercari/tfnotify/v1/pkg/notifier"
+)
+
+// recordFilePermission is the permission of a record, which contains the output of the command
+const recordFilePermission os.FileMode = 0o600
+
+// Record is a notification run saved by `--record`.
+// `tfnotify replay` notifies it again without running the command.
+type Record struct {
+	// Command is the tfnotify command, "plan" or "apply"
+	Command string              `json:"command"`
+	Param   *notifier.ParamExec `json:"param"`
+	CI      config.CI           `json:"ci"`
+	Vars    map[string]string   `json:"vars,omitempty"`
+}
+
+// writeRecord saves the captured output of the command to the file p.
+// The output is masked so that the file can be uploaded as an artifact.
+func (c *Controller) writeRecord(p, command string, param *notifier.ParamExec) error {
+	masked := *param
+	masked.Stdout = mask.Mask(param.Stdout, c.Config.Masks)
+	masked.Stderr = mask.Mask(param.Stderr, c.Config.Masks)
+	masked.CombinedOutput = mask.Mask(param.CombinedOutput, c.Config.Masks)
+	masked.PlanJSON = mask.Mask(param.PlanJSON, c.Config.Masks)
+	vars := make(map[string]string, len(c.Config.Vars))
+	for k, v := range c.Config.Vars {
+		vars[k] = mask.Mask(v, c.Config.Masks)
+	}
+	b, err := json.MarshalIndent(&Record{
+		Command: command,
+		Param:   &masked,
+		CI:      c.Config.CI,
+		Vars:    vars,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode a record as JSON: %w", err)
+	}
+	if err := os.WriteFile(p, b, recordFilePermission); err != nil {
+		return fmt.Errorf("write a record to the file: %w", err)
+	}
+	return nil
+}
+
+// ReadRecord reads a record saved by `--record`
+func ReadRecord(p string) (*Record, error) {
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return nil, fmt.Errorf("read a record: %w", err)
+	}
+	record := &Record{}
+	if err := json.Unmarshal(b, record); err != nil {
+		return nil, fmt.Errorf("parse a record as JSON: %w", err)
+	}
+	if record.Param == nil {
+		return nil, errors.New("param is missing in the record")
+	}
+	return record, nil
+}
+
+// Replay sends the notification of the record with notifier.
+// CI and the variables of the record are used. Variables given by options take precedence.
+func (c *Controller) Replay(ctx context.Context, record *Record) error {
+	c.Config.CI = record.CI
+	vars := maps.Clone(record.Vars)
+	if vars == nil {
+		vars = make(map[string]string)
+	}
+	maps.Copy(vars, c.Config.Vars)
+	c.Config.Vars = vars
+
+	if err := c.Config.Validate(); err != nil {
+		return err
+	}
+
+	var ntf []notifier.Notifier
+	var err error
+	switch record.Command {
+	case "plan":
+		if err := c.setPlanParser(); err != nil {
+			return err
+		}
+		c.planFileParser.PlanJSON = record.Param.PlanJSON
+		ntf, err = c.getPlanNotifier(ctx)
+	case "apply":
+		ntf, err = c.getApplyNotifier(ctx)
+	default:
+		return fmt.Errorf("the command of the record must be either plan or apply: %q", record.Command)
+	}
+	if err != nil {
+		return err
+	}
+	if len(ntf) == 0 {
+		return errors.New("no notifier specified at all")
+	}
+
+	var errs error
+	for _, n := range ntf {
+		if record.Command == "plan" {
+			err = n.Plan(ctx, record.Param)
+		} else {
+			err = n.Apply(ctx, record.Param)
+		}
+		if err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	if record.Command == "plan" {
+		return apperr.NewExitError(c.planExitCode(record.Param.ExitCode, c.Parser.Parse(record.Param.CombinedOutput), errs))
+	}
+	return apperr.NewExitError(record.Param.ExitCode, errs)
+}
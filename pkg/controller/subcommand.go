@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mercari/tfnotify/v1/pkg/apperr"
+	"github.com/mercari/tfnotify/v1/pkg/notifier"
+	"github.com/mercari/tfnotify/v1/pkg/notifier/github"
+	"github.com/mercari/tfnotify/v1/pkg/notifier/localfile"
+	"github.com/mercari/tfnotify/v1/pkg/platform"
+)
+
+// subcommand is a command such as validate and fmt, which runs a Terraform command and notifies the result.
+// Unlike plan and apply, it doesn't read the result from a file or record it.
+type subcommand struct {
+	// name is the name of the command like `fmt`, which is used for the name of the check run
+	name string
+	// patch updates the previous comment instead of posting a new comment
+	patch bool
+	// notify notifies the result with a notifier, e.g. notifier.Notifier.Fmt
+	notify func(n notifier.Notifier, ctx context.Context, param *notifier.ParamExec) error
+	// setGitHubConfig sets the configuration specific to the command to the GitHub notifier. It may be nil
+	setGitHubConfig func(cfg *github.Config) error
+}
+
+// runSubcommand runs the command and sends the result with notifiers
+func (c *Controller) runSubcommand(ctx context.Context, command Command, sub *subcommand) error {
+	if command.Cmd == "" {
+		return errors.New("no command specified")
+	}
+	if err := platform.Complement(&c.Config); err != nil {
+		return err
+	}
+
+	if err := c.Config.Validate(); err != nil {
+		return err
+	}
+
+	if c.Config.Vars == nil {
+		c.Config.Vars = make(map[string]string)
+	}
+	c.Config.Vars["COMMIT_SHA"] = c.Config.CI.SHA
+
+	ntf, err := c.getSubcommandNotifier(ctx, sub)
+	if err != nil {
+		return err
+	}
+	if len(ntf) == 0 {
+		return errors.New("no notifier specified at all")
+	}
+
+	param := c.runCommand(ctx, command)
+
+	// Iterate over notifiers
+	var errs error
+	for _, n := range ntf {
+		if err := sub.notify(n, ctx, param); err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	return apperr.NewExitError(param.ExitCode, errs)
+}
+
+func (c *Controller) getSubcommandNotifier(ctx context.Context, sub *subcommand) ([]notifier.Notifier, error) {
+	var notifiers []notifier.Notifier
+
+	slackNotifier, err := c.getSlackNotifier()
+	if err != nil {
+		return nil, err
+	}
+	if slackNotifier != nil {
+		notifiers = append(notifiers, slackNotifier)
+	}
+
+	checkNotifier, err := c.getCheckNotifier(ctx, sub.name)
+	if err != nil {
+		return nil, err
+	}
+	if checkNotifier != nil {
+		notifiers = append(notifiers, checkNotifier)
+	}
+
+	if c.Config.Output != "" {
+		// Write output to file instead of github comment
+		client, err := localfile.NewClient(&localfile.Config{
+			OutputFile:         c.Config.Output,
+			Parser:             c.Parser,
+			UseRawOutput:       c.Config.Terraform.UseRawOutput,
+			CI:                 c.Config.CI.Link,
+			Template:           c.Template,
+			ParseErrorTemplate: c.ParseErrorTemplate,
+			Vars:               c.Config.Vars,
+			EmbeddedVarNames:   c.Config.EmbeddedVarNames,
+			Templates:          c.Config.Templates,
+			Masks:              c.Config.Masks,
+			DisableLabel:       true,
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, client.Notify)
+		return notifiers, nil
+	}
+
+	cfg := &github.Config{
+		BaseURL:         c.Config.GHEBaseURL,
+		GraphQLEndpoint: c.Config.GHEGraphQLEndpoint,
+		Owner:           c.Config.CI.Owner,
+		Repo:            c.Config.CI.Repo,
+		PR: github.PullRequest{
+			Revision: c.Config.CI.SHA,
+			Number:   c.Config.CI.PRNumber,
+		},
+		CI:                 c.Config.CI.Link,
+		Parser:             c.Parser,
+		UseRawOutput:       c.Config.Terraform.UseRawOutput,
+		Template:           c.Template,
+		ParseErrorTemplate: c.ParseErrorTemplate,
+		Vars:               c.Config.Vars,
+		EmbeddedVarNames:   c.Config.EmbeddedVarNames,
+		Templates:          c.Config.Templates,
+		Patch:              sub.patch,
+		Masks:              c.Config.Masks,
+	}
+	if sub.setGitHubConfig != nil {
+		if err := sub.setGitHubConfig(cfg); err != nil {
+			return nil, err
+		}
+	}
+	client, err := github.NewClient(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	notifiers = append(notifiers, client.Notify)
+	return notifiers, nil
+}
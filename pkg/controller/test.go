@@ -0,0 +1,16 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/mercari/tfnotify/v1/pkg/notifier"
+)
+
+// Test sends the notification with notifier
+func (c *Controller) Test(ctx context.Context, command Command) error {
+	return c.runSubcommand(ctx, command, &subcommand{
+		name:   "test",
+		patch:  c.Config.Terraform.Test.Patch,
+		notify: notifier.Notifier.Test,
+	})
+}
@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/mercari/tfnotify/v1/pkg/notifier"
+	"github.com/mercari/tfnotify/v1/pkg/notifier/github"
+)
+
+// Validate sends the notification with notifier
+func (c *Controller) Validate(ctx context.Context, command Command) error {
+	return c.runSubcommand(ctx, command, &subcommand{
+		name:   "validate",
+		patch:  c.Config.Terraform.Validate.Patch,
+		notify: notifier.Notifier.Validate,
+		setGitHubConfig: func(cfg *github.Config) error {
+			label, labelColor, err := c.renderValidateErrorLabel()
+			if err != nil {
+				return err
+			}
+			cfg.ValidateErrorLabel = label
+			cfg.ValidateErrorLabelColor = labelColor
+			return nil
+		},
+	})
+}
+
+func (c *Controller) renderValidateErrorLabel() (string, string, error) {
+	when := c.Config.Terraform.Validate.WhenValidateError
+	color := when.Color
+	if color == "" {
+		color = "d93f0b" // red
+	}
+	if when.DisableLabel {
+		return "", color, nil
+	}
+	if when.Label == "" {
+		if target := c.Config.Vars["target"]; target != "" {
+			return target + "/validate-error", color, nil
+		}
+		return "validate-error", color, nil
+	}
+	label, err := c.renderTemplate(when.Label)
+	if err != nil {
+		return "", color, err
+	}
+	return label, color, nil
+}
@@ -35,6 +35,10 @@ func (g *NotifyService) Apply(ctx context.Context, param *notifier.ParamExec) er
 		}
 	}
 
+	if cfg.PR.IsNumber() && (cfg.ResultLabels.AppliedLabel != "" || cfg.ResultLabels.ApplyFailedLabel != "") {
+		errMsgs = append(errMsgs, g.UpdateApplyLabels(ctx, result, param.ExitCode)...)
+	}
+
 	// Generate AI summary if summarizer is provided
 	var aiSummary string
 	// Only generate AI summary for failed applies (exit code != 0)
@@ -57,6 +61,8 @@ func (g *NotifyService) Apply(ctx context.Context, param *notifier.ParamExec) er
 			"HasError":               result.HasError,
 			"Warning":                result.Warning,
 			"ChangeOutsideTerraform": result.OutsideTerraform,
+			"Diagnostics":            result.Diagnostics,
+			"AppliedResources":       result.AppliedResources,
 			"ErrorMessages":          errMsgs,
 			"ExitCode":               param.ExitCode,
 			"CombinedOutput":         param.CombinedOutput,
@@ -94,6 +100,12 @@ func (g *NotifyService) Apply(ctx context.Context, param *notifier.ParamExec) er
 		Warning:                result.Warning,
 		HasDestroy:             result.HasDestroy,
 		HasError:               result.HasError,
+		ImportCount:            result.ImportCount,
+		AddCount:               result.AddCount,
+		ChangeCount:            result.ChangeCount,
+		DestroyCount:           result.DestroyCount,
+		MoveCount:              result.MoveCount,
+		ForgetCount:            result.ForgetCount,
 		Link:                   cfg.CI,
 		UseRawOutput:           cfg.UseRawOutput,
 		Vars:                   cfg.Vars,
@@ -107,9 +119,15 @@ func (g *NotifyService) Apply(ctx context.Context, param *notifier.ParamExec) er
 		UpdatedResources:       result.UpdatedResources,
 		DeletedResources:       result.DeletedResources,
 		ReplacedResources:      result.ReplacedResources,
+		Diagnostics:            result.Diagnostics,
+		RunURL:                 result.RunURL,
+		PolicyChecks:           result.PolicyChecks,
+		CostEstimation:         result.CostEstimation,
+		AppliedResources:       result.AppliedResources,
 		ModuleResults:          result.ModuleResults,
 		AISummary:              aiSummary,
 		SummaryEnabled:         param.AISummarizer != nil,
+		Parser:                 result.Parser,
 	})
 	body, err := template.Execute()
 	if err != nil {
@@ -120,7 +138,7 @@ func (g *NotifyService) Apply(ctx context.Context, param *notifier.ParamExec) er
 		"program": "tfnotify",
 	})
 
-	embeddedComment, err := getEmbeddedComment(cfg, param.CIName, false)
+	embeddedComment, err := getEmbeddedComment(cfg, param, "apply", result.Parser)
 	if err != nil {
 		return err
 	}
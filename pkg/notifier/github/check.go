@@ -0,0 +1,263 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/mercari/tfnotify/v1/pkg/config"
+	"github.com/mercari/tfnotify/v1/pkg/mask"
+	"github.com/mercari/tfnotify/v1/pkg/notifier"
+	"github.com/mercari/tfnotify/v1/pkg/terraform"
+	"github.com/sirupsen/logrus"
+)
+
+// Conclusions of check runs
+const (
+	CheckConclusionSuccess        = "success"
+	CheckConclusionNeutral        = "neutral"
+	CheckConclusionActionRequired = "action_required"
+	CheckConclusionFailure        = "failure"
+)
+
+const (
+	// maxCheckRunSummaryLength is the maximum length of the summary of a check run
+	maxCheckRunSummaryLength = 65535
+	// maxCheckRunAnnotations is the maximum number of annotations per request
+	maxCheckRunAnnotations = 50
+)
+
+// CheckService creates check runs with the results of Terraform commands.
+// Unlike comments, check runs can be required by branch protection rules.
+type CheckService service
+
+// Plan creates a check run for terraform plan
+func (g *CheckService) Plan(ctx context.Context, param *notifier.ParamExec) error {
+	result := g.client.Config.Parser.Parse(param.CombinedOutput)
+	result.Cost = param.Cost
+	return g.createCheckRun(ctx, "plan", param, result)
+}
+
+// Apply creates a check run for terraform apply
+func (g *CheckService) Apply(ctx context.Context, param *notifier.ParamExec) error {
+	return g.createCheckRun(ctx, "apply", param, g.client.Config.Parser.Parse(param.CombinedOutput))
+}
+
+// Validate creates a check run for terraform validate
+func (g *CheckService) Validate(ctx context.Context, param *notifier.ParamExec) error {
+	return g.createCheckRun(ctx, "validate", param, g.client.Config.Parser.Parse(param.CombinedOutput))
+}
+
+// Fmt creates a check run for terraform fmt
+func (g *CheckService) Fmt(ctx context.Context, param *notifier.ParamExec) error {
+	return g.createCheckRun(ctx, "fmt", param, terraform.ParseFmt(g.client.Config.Parser, param.CombinedOutput, param.ExitCode))
+}
+
+// Test creates a check run for terraform test
+func (g *CheckService) Test(ctx context.Context, param *notifier.ParamExec) error {
+	return g.createCheckRun(ctx, "test", param, g.client.Config.Parser.Parse(param.CombinedOutput))
+}
+
+// Drift doesn't create a check run because drift is tracked by a GitHub issue
+func (g *CheckService) Drift(_ context.Context, _ *notifier.ParamExec) error {
+	return nil
+}
+
+// createCheckRun creates a completed check run whose summary is the rendered template.
+// Annotations more than the limit of a request are added by updating the check run.
+func (g *CheckService) createCheckRun(ctx context.Context, command string, param *notifier.ParamExec, result terraform.ParseResult) error {
+	cfg := g.client.Config
+	if cfg.PR.Revision == "" {
+		return errors.New("the commit SHA is required to create a check run")
+	}
+	template := cfg.Template
+	if result.HasParseError {
+		template = cfg.ParseErrorTemplate
+	} else if result.Error != nil {
+		return result.Error
+	}
+
+	template.SetValue(terraform.CommonTemplate{
+		Result:                 result.Result,
+		ChangedResult:          result.ChangedResult,
+		ChangeOutsideTerraform: result.OutsideTerraform,
+		Warning:                result.Warning,
+		HasDestroy:             result.HasDestroy,
+		HasError:               result.HasError,
+		ImportCount:            result.ImportCount,
+		AddCount:               result.AddCount,
+		ChangeCount:            result.ChangeCount,
+		DestroyCount:           result.DestroyCount,
+		MoveCount:              result.MoveCount,
+		ForgetCount:            result.ForgetCount,
+		Link:                   cfg.CI,
+		UseRawOutput:           cfg.UseRawOutput,
+		Vars:                   cfg.Vars,
+		Templates:              cfg.Templates,
+		Stdout:                 param.Stdout,
+		Stderr:                 param.Stderr,
+		CombinedOutput:         param.CombinedOutput,
+		ExitCode:               param.ExitCode,
+		CreatedResources:       result.CreatedResources,
+		UpdatedResources:       result.UpdatedResources,
+		DeletedResources:       result.DeletedResources,
+		ReplacedResources:      result.ReplacedResources,
+		MovedResources:         result.MovedResources,
+		ImportedResources:      result.ImportedResources,
+		ForgottenResources:     result.ForgottenResources,
+		DeferredResources:      result.DeferredResources,
+		ReadResources:          result.ReadResources,
+		CreatedAddresses:       result.CreatedAddresses,
+		UpdatedAddresses:       result.UpdatedAddresses,
+		DeletedAddresses:       result.DeletedAddresses,
+		ReplacedAddresses:      result.ReplacedAddresses,
+		ImportedAddresses:      result.ImportedAddresses,
+		ResourceChanges:        result.ResourceChanges,
+		OutputChanges:          result.OutputChanges,
+		Diagnostics:            result.Diagnostics,
+		UnformattedFiles:       result.UnformattedFiles,
+		AppliedResources:       result.AppliedResources,
+		TestFiles:              result.TestFiles,
+		RunURL:                 result.RunURL,
+		PolicyChecks:           result.PolicyChecks,
+		CostEstimation:         result.CostEstimation,
+		Cost:                   result.Cost,
+		Risk:                   result.Risk,
+		ProtectedResources:     result.ProtectedResources,
+		ModuleResults:          result.ModuleResults,
+		Parser:                 result.Parser,
+	})
+	body, err := template.Execute()
+	if err != nil {
+		return err
+	}
+	body = mask.Mask(body, cfg.Masks)
+	if len(body) > maxCheckRunSummaryLength {
+		const suffix = "\n\n... (truncated)"
+		body = strings.ToValidUTF8(body[:maxCheckRunSummaryLength-len(suffix)], "") + suffix
+	}
+
+	name := cfg.CheckRunName
+	if name == "" {
+		name = "tfnotify " + command
+		if target := cfg.Vars["target"]; target != "" {
+			name += " (" + target + ")"
+		}
+	}
+	conclusion := CheckConclusion(command, result, param.ExitCode, cfg.FailOnProtectedResources)
+	title := checkRunTitle(command, conclusion, result)
+	annotations := checkRunAnnotations(result.Diagnostics, cfg.Masks, cfg.CheckRunWorkingDirectory)
+
+	logE := logrus.WithFields(logrus.Fields{
+		"program":    "tfnotify",
+		"check_run":  name,
+		"conclusion": conclusion,
+	})
+
+	opts := github.CreateCheckRunOptions{
+		Name:        name,
+		HeadSHA:     cfg.PR.Revision,
+		Status:      github.Ptr("completed"),
+		Conclusion:  github.Ptr(conclusion),
+		CompletedAt: &github.Timestamp{Time: time.Now()},
+		Output: &github.CheckRunOutput{
+			Title:       github.Ptr(title),
+			Summary:     github.Ptr(body),
+			Annotations: annotations[:min(len(annotations), maxCheckRunAnnotations)],
+		},
+	}
+	if cfg.CI != "" {
+		opts.DetailsURL = github.Ptr(cfg.CI)
+	}
+	logE.Debug("create a check run")
+	checkRun, _, err := g.client.API.ChecksCreateCheckRun(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("create a check run: %w", err)
+	}
+
+	for i := maxCheckRunAnnotations; i < len(annotations); i += maxCheckRunAnnotations {
+		logE.WithField("check_run_id", checkRun.GetID()).Debug("add annotations to a check run")
+		if _, _, err := g.client.API.ChecksUpdateCheckRun(ctx, checkRun.GetID(), github.UpdateCheckRunOptions{
+			Name: name,
+			Output: &github.CheckRunOutput{
+				Title:       github.Ptr(title),
+				Summary:     github.Ptr(body),
+				Annotations: annotations[i:min(len(annotations), i+maxCheckRunAnnotations)],
+			},
+		}); err != nil {
+			return fmt.Errorf("add annotations to a check run: %w", err)
+		}
+	}
+	return nil
+}
+
+// CheckConclusion returns the conclusion of a check run.
+// A plan which destroys resources requires an action, and a plan without changes is neutral.
+// If failOnProtected is true, a plan which deletes or replaces protected resources fails as tfnotify exits with non-zero code.
+func CheckConclusion(command string, result terraform.ParseResult, exitCode int, failOnProtected bool) string {
+	switch {
+	// terraform plan -detailed-exitcode exits with 2 if there are changes
+	case result.HasError || result.HasParseError || (exitCode != 0 && !(command == "plan" && exitCode == 2)): //nolint:mnd
+		return CheckConclusionFailure
+	case command == "plan" && failOnProtected && len(result.ProtectedResources) != 0:
+		return CheckConclusionFailure
+	case command == "plan" && result.HasDestroy:
+		return CheckConclusionActionRequired
+	case result.HasNoChanges:
+		return CheckConclusionNeutral
+	default:
+		return CheckConclusionSuccess
+	}
+}
+
+// checkRunTitle returns the title of a check run, which is shown next to the name of the check run
+func checkRunTitle(command, conclusion string, result terraform.ParseResult) string {
+	switch {
+	case conclusion == CheckConclusionFailure && !result.HasError && !result.HasParseError && len(result.ProtectedResources) != 0:
+		return "Protected resources will be destroyed"
+	case conclusion == CheckConclusionFailure:
+		return "terraform " + command + " failed"
+	case conclusion == CheckConclusionNeutral:
+		return "No changes"
+	case command == "plan":
+		return fmt.Sprintf("%d to add, %d to change, %d to destroy", result.AddCount, result.ChangeCount, result.DestroyCount)
+	default:
+		return "terraform " + command + " succeeded"
+	}
+}
+
+// checkRunAnnotations returns the annotations of diagnostics which have the file and line.
+// The paths of diagnostics are relative to workingDir, so they are joined to it.
+func checkRunAnnotations(diags []*terraform.Diagnostic, masks []*config.Mask, workingDir string) []*github.CheckRunAnnotation {
+	var annotations []*github.CheckRunAnnotation
+	for _, diag := range diags {
+		if diag.File == "" || diag.Line <= 0 {
+			continue
+		}
+		level := "failure"
+		if diag.Severity == terraform.DiagnosticSeverityWarning {
+			level = "warning"
+		}
+		message := diag.Detail
+		if message == "" {
+			message = diag.Summary
+		}
+		annotation := &github.CheckRunAnnotation{
+			Path:            github.Ptr(path.Join(workingDir, diag.File)),
+			StartLine:       github.Ptr(diag.Line),
+			EndLine:         github.Ptr(diag.Line),
+			AnnotationLevel: github.Ptr(level),
+			Title:           github.Ptr(mask.Mask(diag.Summary, masks)),
+			Message:         github.Ptr(mask.Mask(message, masks)),
+		}
+		if diag.Snippet != "" {
+			annotation.RawDetails = github.Ptr(mask.Mask(diag.Snippet, masks))
+		}
+		annotations = append(annotations, annotation)
+	}
+	return annotations
+}
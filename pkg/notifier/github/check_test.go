@@ -0,0 +1,162 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/mercari/tfnotify/v1/pkg/notifier"
+	"github.com/mercari/tfnotify/v1/pkg/terraform"
+)
+
+func TestCheckConclusion(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name            string
+		command         string
+		result          terraform.ParseResult
+		exitCode        int
+		failOnProtected bool
+		exp             string
+	}{
+		{
+			name:    "add or update",
+			command: "plan",
+			result:  terraform.ParseResult{HasAddOrUpdateOnly: true},
+			exp:     CheckConclusionSuccess,
+		},
+		{
+			name:     "detailed exit code",
+			command:  "plan",
+			result:   terraform.ParseResult{HasAddOrUpdateOnly: true},
+			exitCode: 2,
+			exp:      CheckConclusionSuccess,
+		},
+		{
+			name:    "no changes",
+			command: "plan",
+			result:  terraform.ParseResult{HasNoChanges: true},
+			exp:     CheckConclusionNeutral,
+		},
+		{
+			name:     "destroy",
+			command:  "plan",
+			result:   terraform.ParseResult{HasDestroy: true},
+			exitCode: 2,
+			exp:      CheckConclusionActionRequired,
+		},
+		{
+			name:            "protected resources are destroyed",
+			command:         "plan",
+			result:          terraform.ParseResult{HasDestroy: true, ProtectedResources: []string{"aws_kms_key.main"}},
+			exitCode:        2,
+			failOnProtected: true,
+			exp:             CheckConclusionFailure,
+		},
+		{
+			name:     "protected resources are destroyed without fail",
+			command:  "plan",
+			result:   terraform.ParseResult{HasDestroy: true, ProtectedResources: []string{"aws_kms_key.main"}},
+			exitCode: 2,
+			exp:      CheckConclusionActionRequired,
+		},
+		{
+			name:    "apply destroy",
+			command: "apply",
+			result:  terraform.ParseResult{HasDestroy: true},
+			exp:     CheckConclusionSuccess,
+		},
+		{
+			name:     "plan error",
+			command:  "plan",
+			result:   terraform.ParseResult{HasError: true},
+			exitCode: 1,
+			exp:      CheckConclusionFailure,
+		},
+		{
+			name:     "apply exit code",
+			command:  "apply",
+			exitCode: 2,
+			exp:      CheckConclusionFailure,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			if c := CheckConclusion(testCase.command, testCase.result, testCase.exitCode, testCase.failOnProtected); c != testCase.exp {
+				t.Errorf("wanted %s, got %s", testCase.exp, c)
+			}
+		})
+	}
+}
+
+func TestCheckValidate(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "xxx")
+	client, err := NewClient(t.Context(), &Config{
+		Owner: "owner",
+		Repo:  "repo",
+		PR: PullRequest{
+			Revision: "revision",
+		},
+		CI:                 "https://example.com/build/1",
+		Vars:               map[string]string{"target": "foo"},
+		Parser:             terraform.NewValidateParser(),
+		Template:           terraform.NewValidateTemplate(""),
+		ParseErrorTemplate: terraform.NewValidateParseErrorTemplate(""),
+		// terraform runs in the directory of the target
+		CheckRunWorkingDirectory: "envs/foo",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b strings.Builder
+	b.WriteString("\nError: Unsupported argument\n\n  on main.tf line 1, in resource \"null_resource\" \"foo\":\n   1:   foo = 1\n\nAn argument named \"foo\" is not expected here.\n")
+	for i := range 60 {
+		fmt.Fprintf(&b, "\nWarning: Deprecated attribute\n\n  on main.tf line %d:\n\nThe attribute is deprecated.\n", i+2)
+	}
+	api := newFakeAPI()
+	var created github.CreateCheckRunOptions
+	api.FakeChecksCreateCheckRun = func(ctx context.Context, opts github.CreateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
+		created = opts
+		return &github.CheckRun{ID: github.Ptr(int64(4))}, nil, nil
+	}
+	var updated []github.UpdateCheckRunOptions
+	api.FakeChecksUpdateCheckRun = func(ctx context.Context, checkRunID int64, opts github.UpdateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
+		if checkRunID != 4 {
+			t.Errorf("the created check run must be updated: %d", checkRunID)
+		}
+		updated = append(updated, opts)
+		return &github.CheckRun{ID: github.Ptr(checkRunID)}, nil, nil
+	}
+	client.API = &api
+	if err := client.Check.Validate(t.Context(), &notifier.ParamExec{
+		CombinedOutput: b.String(),
+		ExitCode:       1,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if created.Name != "tfnotify validate (foo)" || created.HeadSHA != "revision" {
+		t.Errorf("name or head sha is wrong: %s %s", created.Name, created.HeadSHA)
+	}
+	if created.GetConclusion() != CheckConclusionFailure || created.GetDetailsURL() != "https://example.com/build/1" {
+		t.Errorf("conclusion or details url is wrong: %s %s", created.GetConclusion(), created.GetDetailsURL())
+	}
+	if created.GetOutput().GetSummary() == "" {
+		t.Error("the summary must be the rendered template")
+	}
+	annotations := created.GetOutput().Annotations
+	if len(annotations) != maxCheckRunAnnotations {
+		t.Fatalf("the annotations must be limited: %d", len(annotations))
+	}
+	if a := annotations[0]; a.GetPath() != "envs/foo/main.tf" || a.GetStartLine() != 1 || a.GetAnnotationLevel() != "failure" || a.GetTitle() != "Unsupported argument" {
+		t.Errorf("the annotation of the error is wrong: %+v", a)
+	}
+	if a := annotations[1]; a.GetAnnotationLevel() != "warning" {
+		t.Errorf("the annotation of the warning is wrong: %+v", a)
+	}
+	if len(updated) != 1 || len(updated[0].GetOutput().Annotations) != 11 {
+		t.Errorf("the rest of annotations must be added by updating the check run: %+v", updated)
+	}
+}
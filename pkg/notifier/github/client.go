@@ -26,7 +26,9 @@ type Client struct {
 	Comment  *CommentService
 	Commits  *CommitsService
 	Notify   *NotifyService
+	Check    *CheckService
 	User     *UserService
+	Issue    *IssueService
 	v4Client *githubv4.Client
 	API      API
 }
@@ -53,6 +55,28 @@ type Config struct {
 	SkipNoChanges    bool
 	IgnoreWarning    bool
 	Masks            []*config.Mask
+
+	// ValidateErrorLabel is added when the configuration is invalid, and removed when it's valid
+	ValidateErrorLabel      string
+	ValidateErrorLabelColor string
+
+	// DriftIssue is the GitHub issue to track drift
+	DriftIssue DriftIssue
+
+	// CheckRunName is the name of check runs created by CheckService
+	CheckRunName string
+	// CheckRunWorkingDirectory is the directory where Terraform runs, relative to the repository root.
+	// The paths of annotations are relative to it, while check runs require paths relative to the repository root.
+	CheckRunWorkingDirectory string
+	// FailOnProtectedResources makes check runs of plans fail if protected resources are deleted or replaced
+	FailOnProtectedResources bool
+}
+
+// DriftIssue is a configuration of the GitHub issue to track drift
+type DriftIssue struct {
+	Title     string
+	Labels    []string
+	Assignees []string
 }
 
 // PullRequest represents GitHub Pull Request metadata
@@ -114,7 +138,9 @@ func NewClient(ctx context.Context, cfg *Config) (*Client, error) {
 	c.Comment = (*CommentService)(&c.common)
 	c.Commits = (*CommitsService)(&c.common)
 	c.Notify = (*NotifyService)(&c.common)
+	c.Check = (*CheckService)(&c.common)
 	c.User = (*UserService)(&c.common)
+	c.Issue = (*IssueService)(&c.common)
 
 	c.API = &GitHub{
 		Client: client,
@@ -140,11 +166,48 @@ type ResultLabels struct {
 	DestroyLabelColor     string
 	NoChangesLabelColor   string
 	PlanErrorLabelColor   string
+	// AppliedLabel and ApplyFailedLabel replace the labels above when terraform apply succeeds or fails
+	AppliedLabel          string
+	ApplyFailedLabel      string
+	AppliedLabelColor     string
+	ApplyFailedLabelColor string
+	// CostIncreaseLabel is added independently of the other labels when the monthly cost increases more than CostIncreaseThreshold
+	CostIncreaseLabel      string
+	CostIncreaseLabelColor string
+	CostIncreaseThreshold  float64
+	// RiskLabels is the labels per risk level, which are added independently of the other labels
+	RiskLabels      map[string]string
+	RiskLabelColors map[string]string
+	// ProtectedLabel is added independently of the other labels when protected resources are deleted or replaced
+	ProtectedLabel      string
+	ProtectedLabelColor string
+	// ConditionalLabels is added independently of the other labels when their conditions are satisfied
+	ConditionalLabels []*ConditionalLabel
+	// ModuleLabels is added per Terragrunt module in consolidated mode, independently of the other labels
+	ModuleLabels []*ModuleLabel
+}
+
+// ConditionalLabel is a label added when When is satisfied, and removed otherwise
+type ConditionalLabel struct {
+	Name        string
+	Color       string
+	Description string
+	// When is a condition evaluated by terraform.EvaluateCondition
+	When string
+}
+
+// ModuleLabel is a label added per Terragrunt module whose changes satisfy When, and removed when they don't
+type ModuleLabel struct {
+	Label       *terraform.ModuleLabel
+	Color       string
+	Description string
+	// When is a condition evaluated against the changes of each module
+	When string
 }
 
 // HasAnyLabelDefined returns true if any of the internal labels are set
 func (r *ResultLabels) HasAnyLabelDefined() bool {
-	return r.AddOrUpdateLabel != "" || r.DestroyLabel != "" || r.NoChangesLabel != "" || r.PlanErrorLabel != ""
+	return r.AddOrUpdateLabel != "" || r.DestroyLabel != "" || r.NoChangesLabel != "" || r.PlanErrorLabel != "" || r.CostIncreaseLabel != "" || len(r.RiskLabels) != 0 || r.ProtectedLabel != "" || len(r.ConditionalLabels) != 0 || len(r.ModuleLabels) != 0
 }
 
 // IsResultLabel returns true if a label matches any of the internal labels
@@ -152,7 +215,7 @@ func (r *ResultLabels) IsResultLabel(label string) bool {
 	switch label {
 	case "":
 		return false
-	case r.AddOrUpdateLabel, r.DestroyLabel, r.NoChangesLabel, r.PlanErrorLabel:
+	case r.AddOrUpdateLabel, r.DestroyLabel, r.NoChangesLabel, r.PlanErrorLabel, r.AppliedLabel, r.ApplyFailedLabel:
 		return true
 	default:
 		return false
@@ -203,6 +203,18 @@ func TestHasAnyLabelDefined(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			rl: ResultLabels{
+				CostIncreaseLabel: "cost-increase",
+			},
+			want: true,
+		},
+		{
+			rl: ResultLabels{
+				ConditionalLabels: []*ConditionalLabel{{Name: "iam-change"}},
+			},
+			want: true,
+		},
 		{
 			rl:   ResultLabels{},
 			want: false,
@@ -0,0 +1,108 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/mercari/tfnotify/v1/pkg/mask"
+	"github.com/mercari/tfnotify/v1/pkg/notifier"
+	"github.com/mercari/tfnotify/v1/pkg/terraform"
+	"github.com/sirupsen/logrus"
+)
+
+// Drift opens or updates the issue to track drift.
+// One issue is managed per target, and it is closed when the drift goes away.
+func (g *NotifyService) Drift(ctx context.Context, param *notifier.ParamExec) error {
+	cfg := g.client.Config
+	parser := g.client.Config.Parser
+	template := g.client.Config.Template
+
+	result := parser.Parse(param.CombinedOutput)
+	if result.HasParseError {
+		template = g.client.Config.ParseErrorTemplate
+	} else if result.Error != nil {
+		return result.Error
+	}
+
+	template.SetValue(terraform.CommonTemplate{
+		Result:                 result.Result,
+		ChangeOutsideTerraform: result.OutsideTerraform,
+		HasError:               result.HasError,
+		Link:                   cfg.CI,
+		UseRawOutput:           cfg.UseRawOutput,
+		Vars:                   cfg.Vars,
+		Templates:              cfg.Templates,
+		Stdout:                 param.Stdout,
+		Stderr:                 param.Stderr,
+		CombinedOutput:         param.CombinedOutput,
+		ExitCode:               param.ExitCode,
+		Diagnostics:            result.Diagnostics,
+		DriftedResources:       result.DriftedResources,
+	})
+	body, err := template.Execute()
+	if err != nil {
+		return err
+	}
+
+	logE := logrus.WithFields(logrus.Fields{
+		"program": "tfnotify",
+	})
+
+	// A failed plan isn't drift. Credentials, backend locks, and provider errors mustn't open or close the issue
+	if result.HasParseError || result.HasError || param.ExitCode == 1 {
+		return errors.New("terraform plan failed, so the drift issue isn't updated")
+	}
+
+	embeddedComment, err := getEmbeddedComment(cfg, param, "drift", "")
+	if err != nil {
+		return err
+	}
+	logE.WithFields(logrus.Fields{
+		"comment": embeddedComment,
+	}).Debug("embedded HTML comment")
+
+	body = mask.Mask(body, g.client.Config.Masks)
+
+	issue, err := g.client.Issue.Find(ctx, logE, cfg.DriftIssue.Labels, cfg.Vars["target"], "drift")
+	if err != nil {
+		return err
+	}
+
+	// The exit code 2 of -detailed-exitcode means drift
+	hasDrift := param.ExitCode == 2 || result.OutsideTerraform != ""
+	if !hasDrift {
+		if issue == nil {
+			logE.Debug("no drift and no issue")
+			return nil
+		}
+		logE.WithField("issue_number", issue.GetNumber()).Info("close the drift issue because the drift has gone")
+		return g.client.Issue.Close(ctx, issue.GetNumber(), body+mask.Mask(embeddedComment, cfg.Masks))
+	}
+
+	if issue != nil {
+		// The metadata includes the information of each CI run, so it is ignored
+		if removeEmbeddedComment(issue.GetBody()) == body {
+			logE.Debug("issue isn't changed")
+			return nil
+		}
+		logE.WithField("issue_number", issue.GetNumber()).Info("update the drift issue")
+		return g.client.Issue.Update(ctx, issue.GetNumber(), body+mask.Mask(embeddedComment, cfg.Masks))
+	}
+
+	// embed HTML tag to find the issue
+	number, err := g.client.Issue.Create(ctx, cfg.DriftIssue.Title, body+mask.Mask(embeddedComment, cfg.Masks), cfg.DriftIssue.Labels, cfg.DriftIssue.Assignees)
+	if err != nil {
+		return err
+	}
+	logE.WithField("issue_number", number).Info("created the drift issue")
+	return nil
+}
+
+// removeEmbeddedComment returns body without the metadata embedded by getEmbeddedComment
+func removeEmbeddedComment(body string) string {
+	if i := strings.LastIndex(body, "\n<!-- github-comment: "); i != -1 {
+		return body[:i]
+	}
+	return body
+}
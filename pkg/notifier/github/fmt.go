@@ -0,0 +1,70 @@
+package github
+
+import (
+	"context"
+
+	"github.com/mercari/tfnotify/v1/pkg/mask"
+	"github.com/mercari/tfnotify/v1/pkg/notifier"
+	"github.com/mercari/tfnotify/v1/pkg/terraform"
+	"github.com/sirupsen/logrus"
+)
+
+// Fmt posts comment for terraform fmt -check.
+// A comment isn't posted if all files are formatted,
+// but the previous comment is updated to the success if patch is enabled.
+func (g *NotifyService) Fmt(ctx context.Context, param *notifier.ParamExec) error {
+	cfg := g.client.Config
+	parser := g.client.Config.Parser
+	template := g.client.Config.Template
+
+	if cfg.PR.Number == 0 && cfg.PR.Revision != "" {
+		if prNumber, err := g.client.Commits.PRNumber(ctx, cfg.PR.Revision); err == nil {
+			cfg.PR.Number = prNumber
+		}
+	}
+
+	result := terraform.ParseFmt(parser, param.CombinedOutput, param.ExitCode)
+	if result.HasParseError {
+		template = g.client.Config.ParseErrorTemplate
+	} else if result.Error != nil {
+		return result.Error
+	}
+
+	template.SetValue(terraform.CommonTemplate{
+		Result:           result.Result,
+		HasError:         result.HasError,
+		Link:             cfg.CI,
+		UseRawOutput:     cfg.UseRawOutput,
+		Vars:             cfg.Vars,
+		Templates:        cfg.Templates,
+		Stdout:           param.Stdout,
+		Stderr:           param.Stderr,
+		CombinedOutput:   param.CombinedOutput,
+		ExitCode:         param.ExitCode,
+		Diagnostics:      result.Diagnostics,
+		UnformattedFiles: result.UnformattedFiles,
+	})
+	body, err := template.Execute()
+	if err != nil {
+		return err
+	}
+
+	logE := logrus.WithFields(logrus.Fields{
+		"program": "tfnotify",
+	})
+
+	embeddedComment, err := getEmbeddedComment(cfg, param, "fmt", "")
+	if err != nil {
+		return err
+	}
+	logE.WithFields(logrus.Fields{
+		"comment": embeddedComment,
+	}).Debug("embedded HTML comment")
+	// embed HTML tag to hide old comments
+	body += embeddedComment
+
+	body = mask.Mask(body, g.client.Config.Masks)
+
+	isFormatted := !result.HasParseError && !result.HasError && len(result.UnformattedFiles) == 0 && param.ExitCode == 0
+	return g.postOrPatchComment(ctx, logE, body, "fmt", isFormatted)
+}
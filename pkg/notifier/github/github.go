@@ -14,8 +14,14 @@ type API interface {
 	IssuesAddLabels(ctx context.Context, number int, labels []string) ([]*github.Label, *github.Response, error)
 	IssuesRemoveLabel(ctx context.Context, number int, label string) (*github.Response, error)
 	IssuesUpdateLabel(ctx context.Context, label, color string) (*github.Label, *github.Response, error)
+	IssuesEditLabel(ctx context.Context, name string, label *github.Label) (*github.Label, *github.Response, error)
 	RepositoriesCreateComment(ctx context.Context, sha string, comment *github.RepositoryComment) (*github.RepositoryComment, *github.Response, error)
 	PullRequestsListPullRequestsWithCommit(ctx context.Context, sha string, opt *github.ListOptions) ([]*github.PullRequest, *github.Response, error)
+	IssuesListByRepo(ctx context.Context, opt *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error)
+	IssuesCreate(ctx context.Context, issue *github.IssueRequest) (*github.Issue, *github.Response, error)
+	IssuesEdit(ctx context.Context, number int, issue *github.IssueRequest) (*github.Issue, *github.Response, error)
+	ChecksCreateCheckRun(ctx context.Context, opts github.CreateCheckRunOptions) (*github.CheckRun, *github.Response, error)
+	ChecksUpdateCheckRun(ctx context.Context, checkRunID int64, opts github.UpdateCheckRunOptions) (*github.CheckRun, *github.Response, error)
 }
 
 // GitHub represents the attribute information necessary for requesting GitHub API
@@ -57,6 +63,11 @@ func (g *GitHub) IssuesUpdateLabel(ctx context.Context, label, color string) (*g
 	})
 }
 
+// IssuesEditLabel is a wrapper of https://pkg.go.dev/github.com/google/go-github/github#IssuesService.EditLabel
+func (g *GitHub) IssuesEditLabel(ctx context.Context, name string, label *github.Label) (*github.Label, *github.Response, error) {
+	return g.Issues.EditLabel(ctx, g.owner, g.repo, name, label)
+}
+
 // RepositoriesCreateComment is a wrapper of https://godoc.org/github.com/google/go-github/github#RepositoriesService.CreateComment
 func (g *GitHub) RepositoriesCreateComment(ctx context.Context, sha string, comment *github.RepositoryComment) (*github.RepositoryComment, *github.Response, error) {
 	return g.Repositories.CreateComment(ctx, g.owner, g.repo, sha, comment)
@@ -65,3 +76,28 @@ func (g *GitHub) RepositoriesCreateComment(ctx context.Context, sha string, comm
 func (g *GitHub) PullRequestsListPullRequestsWithCommit(ctx context.Context, sha string, opt *github.ListOptions) ([]*github.PullRequest, *github.Response, error) {
 	return g.PullRequests.ListPullRequestsWithCommit(ctx, g.owner, g.repo, sha, opt)
 }
+
+// IssuesListByRepo is a wrapper of https://pkg.go.dev/github.com/google/go-github/github#IssuesService.ListByRepo
+func (g *GitHub) IssuesListByRepo(ctx context.Context, opt *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error) {
+	return g.Issues.ListByRepo(ctx, g.owner, g.repo, opt)
+}
+
+// IssuesCreate is a wrapper of https://pkg.go.dev/github.com/google/go-github/github#IssuesService.Create
+func (g *GitHub) IssuesCreate(ctx context.Context, issue *github.IssueRequest) (*github.Issue, *github.Response, error) {
+	return g.Issues.Create(ctx, g.owner, g.repo, issue)
+}
+
+// IssuesEdit is a wrapper of https://pkg.go.dev/github.com/google/go-github/github#IssuesService.Edit
+func (g *GitHub) IssuesEdit(ctx context.Context, number int, issue *github.IssueRequest) (*github.Issue, *github.Response, error) {
+	return g.Issues.Edit(ctx, g.owner, g.repo, number, issue)
+}
+
+// ChecksCreateCheckRun is a wrapper of https://pkg.go.dev/github.com/google/go-github/github#ChecksService.CreateCheckRun
+func (g *GitHub) ChecksCreateCheckRun(ctx context.Context, opts github.CreateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
+	return g.Checks.CreateCheckRun(ctx, g.owner, g.repo, opts)
+}
+
+// ChecksUpdateCheckRun is a wrapper of https://pkg.go.dev/github.com/google/go-github/github#ChecksService.UpdateCheckRun
+func (g *GitHub) ChecksUpdateCheckRun(ctx context.Context, checkRunID int64, opts github.UpdateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
+	return g.Checks.UpdateCheckRun(ctx, g.owner, g.repo, checkRunID, opts)
+}
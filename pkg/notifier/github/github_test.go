@@ -15,10 +15,16 @@ type fakeAPI struct {
 	FakeIssuesListLabels                       func(ctx context.Context, number int, opts *github.ListOptions) ([]*github.Label, *github.Response, error)
 	FakeIssuesAddLabels                        func(ctx context.Context, number int, labels []string) ([]*github.Label, *github.Response, error)
 	FakeIssuesRemoveLabel                      func(ctx context.Context, number int, label string) (*github.Response, error)
+	FakeIssuesEditLabel                        func(ctx context.Context, name string, label *github.Label) (*github.Label, *github.Response, error)
 	FakeRepositoriesCreateComment              func(ctx context.Context, sha string, comment *github.RepositoryComment) (*github.RepositoryComment, *github.Response, error)
 	FakeRepositoriesListCommits                func(ctx context.Context, opt *github.CommitsListOptions) ([]*github.RepositoryCommit, *github.Response, error)
 	FakeRepositoriesGetCommit                  func(ctx context.Context, sha string) (*github.RepositoryCommit, *github.Response, error)
 	FakePullRequestsListPullRequestsWithCommit func(ctx context.Context, sha string, opt *github.ListOptions) ([]*github.PullRequest, *github.Response, error)
+	FakeIssuesListByRepo                       func(ctx context.Context, opt *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error)
+	FakeIssuesCreate                           func(ctx context.Context, issue *github.IssueRequest) (*github.Issue, *github.Response, error)
+	FakeIssuesEdit                             func(ctx context.Context, number int, issue *github.IssueRequest) (*github.Issue, *github.Response, error)
+	FakeChecksCreateCheckRun                   func(ctx context.Context, opts github.CreateCheckRunOptions) (*github.CheckRun, *github.Response, error)
+	FakeChecksUpdateCheckRun                   func(ctx context.Context, checkRunID int64, opts github.UpdateCheckRunOptions) (*github.CheckRun, *github.Response, error)
 }
 
 func (g *fakeAPI) IssuesCreateComment(ctx context.Context, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
@@ -37,6 +43,10 @@ func (g *fakeAPI) IssuesRemoveLabel(ctx context.Context, number int, label strin
 	return g.FakeIssuesRemoveLabel(ctx, number, label)
 }
 
+func (g *fakeAPI) IssuesEditLabel(ctx context.Context, name string, label *github.Label) (*github.Label, *github.Response, error) {
+	return g.FakeIssuesEditLabel(ctx, name, label)
+}
+
 func (g *fakeAPI) RepositoriesCreateComment(ctx context.Context, sha string, comment *github.RepositoryComment) (*github.RepositoryComment, *github.Response, error) {
 	return g.FakeRepositoriesCreateComment(ctx, sha, comment)
 }
@@ -53,6 +63,26 @@ func (g *fakeAPI) PullRequestsListPullRequestsWithCommit(ctx context.Context, sh
 	return g.FakePullRequestsListPullRequestsWithCommit(ctx, sha, opt)
 }
 
+func (g *fakeAPI) IssuesListByRepo(ctx context.Context, opt *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error) {
+	return g.FakeIssuesListByRepo(ctx, opt)
+}
+
+func (g *fakeAPI) IssuesCreate(ctx context.Context, issue *github.IssueRequest) (*github.Issue, *github.Response, error) {
+	return g.FakeIssuesCreate(ctx, issue)
+}
+
+func (g *fakeAPI) IssuesEdit(ctx context.Context, number int, issue *github.IssueRequest) (*github.Issue, *github.Response, error) {
+	return g.FakeIssuesEdit(ctx, number, issue)
+}
+
+func (g *fakeAPI) ChecksCreateCheckRun(ctx context.Context, opts github.CreateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
+	return g.FakeChecksCreateCheckRun(ctx, opts)
+}
+
+func (g *fakeAPI) ChecksUpdateCheckRun(ctx context.Context, checkRunID int64, opts github.UpdateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
+	return g.FakeChecksUpdateCheckRun(ctx, checkRunID, opts)
+}
+
 func newFakeAPI() fakeAPI {
 	return fakeAPI{
 		FakeIssuesCreateComment: func(ctx context.Context, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
@@ -121,6 +151,34 @@ func newFakeAPI() fakeAPI {
 				},
 			}, nil, nil
 		},
+		FakeIssuesListByRepo: func(ctx context.Context, opt *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error) {
+			return nil, nil, nil
+		},
+		FakeIssuesCreate: func(ctx context.Context, issue *github.IssueRequest) (*github.Issue, *github.Response, error) {
+			return &github.Issue{
+				Number: github.Ptr(10),
+				Title:  issue.Title,
+				Body:   issue.Body,
+			}, nil, nil
+		},
+		FakeIssuesEdit: func(ctx context.Context, number int, issue *github.IssueRequest) (*github.Issue, *github.Response, error) {
+			return &github.Issue{
+				Number: github.Ptr(number),
+				Body:   issue.Body,
+			}, nil, nil
+		},
+		FakeChecksCreateCheckRun: func(ctx context.Context, opts github.CreateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
+			return &github.CheckRun{
+				ID:   github.Ptr(int64(4)),
+				Name: github.Ptr(opts.Name),
+			}, nil, nil
+		},
+		FakeChecksUpdateCheckRun: func(ctx context.Context, checkRunID int64, opts github.UpdateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
+			return &github.CheckRun{
+				ID:   github.Ptr(checkRunID),
+				Name: github.Ptr(opts.Name),
+			}, nil, nil
+		},
 	}
 }
 
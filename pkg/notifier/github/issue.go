@@ -0,0 +1,92 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/sirupsen/logrus"
+	"github.com/suzuki-shunsuke/github-comment-metadata/metadata"
+)
+
+// IssueService handles communication with the issue related
+// methods of GitHub API
+type IssueService service
+
+// Find returns the open issue created by tfnotify for the command and the target.
+// The issue is identified by the metadata embedded in the issue body.
+// nil is returned if the issue isn't found.
+func (g *IssueService) Find(ctx context.Context, logE *logrus.Entry, labels []string, target, command string) (*github.Issue, error) {
+	opt := &github.IssueListByRepoOptions{
+		State:  "open",
+		Labels: labels,
+		ListOptions: github.ListOptions{
+			PerPage: 100, //nolint:mnd
+		},
+	}
+	for {
+		issues, resp, err := g.client.API.IssuesListByRepo(ctx, opt)
+		if err != nil {
+			return nil, fmt.Errorf("list issues: %w", err)
+		}
+		for _, issue := range issues {
+			if issue.IsPullRequest() {
+				continue
+			}
+			data := &Metadata{}
+			f, err := metadata.Extract(issue.GetBody(), data)
+			if err != nil {
+				logE.WithError(err).WithField("issue_number", issue.GetNumber()).Debug("extract metadata from issue")
+				continue
+			}
+			if f && data.Program == "tfnotify" && data.Command == command && data.Target == target {
+				return issue, nil
+			}
+		}
+		if resp == nil || resp.NextPage == 0 {
+			return nil, nil //nolint:nilnil
+		}
+		opt.ListOptions.Page = resp.NextPage
+	}
+}
+
+// Create creates an issue and returns the issue number
+func (g *IssueService) Create(ctx context.Context, title, body string, labels, assignees []string) (int, error) {
+	req := &github.IssueRequest{
+		Title: &title,
+		Body:  &body,
+	}
+	if len(labels) != 0 {
+		req.Labels = &labels
+	}
+	if len(assignees) != 0 {
+		req.Assignees = &assignees
+	}
+	issue, _, err := g.client.API.IssuesCreate(ctx, req)
+	if err != nil {
+		return 0, fmt.Errorf("create an issue: %w", err)
+	}
+	return issue.GetNumber(), nil
+}
+
+// Update updates the body of an issue
+func (g *IssueService) Update(ctx context.Context, number int, body string) error {
+	if _, _, err := g.client.API.IssuesEdit(ctx, number, &github.IssueRequest{
+		Body: &body,
+	}); err != nil {
+		return fmt.Errorf("update an issue: %w", err)
+	}
+	return nil
+}
+
+// Close updates the body of an issue and closes it as completed
+func (g *IssueService) Close(ctx context.Context, number int, body string) error {
+	if _, _, err := g.client.API.IssuesEdit(ctx, number, &github.IssueRequest{
+		Body:        &body,
+		State:       github.Ptr("closed"),
+		StateReason: github.Ptr("completed"),
+	}); err != nil {
+		return fmt.Errorf("close an issue: %w", err)
+	}
+	return nil
+}
@@ -4,13 +4,74 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
 
 	"github.com/google/go-github/v74/github"
 	"github.com/mercari/tfnotify/v1/pkg/terraform"
 	"github.com/sirupsen/logrus"
 )
 
-func (g *NotifyService) UpdateLabels(ctx context.Context, result terraform.ParseResult) []string { //nolint:cyclop
+// labelUpdate is the labels which tfnotify adds to a pull request and the labels which tfnotify manages.
+// Managed labels which aren't added are removed from the pull request.
+type labelUpdate struct {
+	labels    []*labelToAdd
+	isManaged []func(label string) bool
+	errMsgs   []string
+}
+
+// labelToAdd is a label added to a pull request with its color and description
+type labelToAdd struct {
+	name        string
+	color       string
+	description string
+}
+
+// add adds a label to the pull request. It is ignored if name is empty.
+func (u *labelUpdate) add(name, color, description string) {
+	if name == "" || u.has(name) {
+		return
+	}
+	if len(name) > 50 { //nolint:mnd
+		u.errMsgs = append(u.errMsgs, fmt.Sprintf("failed to add a label %s: label name is too long (max: 50)", name))
+		return
+	}
+	u.labels = append(u.labels, &labelToAdd{
+		name:        name,
+		color:       color,
+		description: description,
+	})
+}
+
+// manage adds labels managed by tfnotify, which are removed unless they are added.
+func (u *labelUpdate) manage(isManaged func(label string) bool) {
+	u.isManaged = append(u.isManaged, isManaged)
+}
+
+func (u *labelUpdate) has(name string) bool {
+	return slices.ContainsFunc(u.labels, func(l *labelToAdd) bool {
+		return l.name == name
+	})
+}
+
+func (u *labelUpdate) managed(name string) bool {
+	return slices.ContainsFunc(u.isManaged, func(isManaged func(label string) bool) bool {
+		return isManaged(name)
+	})
+}
+
+// get returns the label to add whose name is name, or nil
+func (u *labelUpdate) get(name string) *labelToAdd {
+	for _, l := range u.labels {
+		if l.name == name {
+			return l
+		}
+	}
+	return nil
+}
+
+// UpdateLabels updates the labels of the plan result.
+// The labels of the pull request are listed once, and all labels are added and removed at once.
+func (g *NotifyService) UpdateLabels(ctx context.Context, result terraform.ParseResult) []string {
 	cfg := g.client.Config
 	var (
 		labelToAdd string
@@ -35,92 +96,263 @@ func (g *NotifyService) UpdateLabels(ctx context.Context, result terraform.Parse
 		labelColor = cfg.ResultLabels.PlanErrorLabelColor
 	}
 
-	errMsgs := []string{}
+	update := &labelUpdate{}
+	update.add(labelToAdd, labelColor, "")
+	update.manage(cfg.ResultLabels.IsResultLabel)
+	if cfg.ResultLabels.CostIncreaseLabel != "" {
+		g.setCostLabel(update, result)
+	}
+	if len(cfg.ResultLabels.RiskLabels) != 0 {
+		g.setRiskLabel(update, result)
+	}
+	if cfg.ResultLabels.ProtectedLabel != "" {
+		if len(result.ProtectedResources) != 0 {
+			update.add(cfg.ResultLabels.ProtectedLabel, cfg.ResultLabels.ProtectedLabelColor, "")
+		}
+		update.manage(func(label string) bool {
+			return label == cfg.ResultLabels.ProtectedLabel
+		})
+	}
+	for _, label := range cfg.ResultLabels.ConditionalLabels {
+		g.setConditionalLabel(update, label, result)
+	}
+	if !result.HasError {
+		for _, label := range cfg.ResultLabels.ModuleLabels {
+			g.setModuleLabel(update, label, result)
+		}
+	}
+	return g.applyLabelUpdate(ctx, update)
+}
 
+// setModuleLabel adds the labels of the Terragrunt modules whose changes satisfy the condition,
+// and removes the labels of the other modules in the result including modules without changes.
+// Labels of modules which aren't in the result are kept, because they may be managed by other targets.
+func (g *NotifyService) setModuleLabel(update *labelUpdate, label *ModuleLabel, result terraform.ParseResult) {
+	cfg := g.client.Config
 	logE := logrus.WithFields(logrus.Fields{
 		"program": "tfnotify",
 	})
 
-	currentLabelColor, err := g.removeResultLabels(ctx, labelToAdd)
-	if err != nil {
-		msg := "remove labels: " + err.Error()
-		logE.WithError(err).Error("remove labels")
-		errMsgs = append(errMsgs, msg)
+	// the labels of the modules in the result, which are removed unless they are added
+	managedLabels := map[string]struct{}{}
+	for _, module := range result.Modules {
+		name, err := label.Label.Render(module)
+		if err != nil {
+			logE.WithError(err).WithField("module", module).Error("render a module label")
+			update.errMsgs = append(update.errMsgs, "render a module label (module: "+module+"): "+err.Error())
+			continue
+		}
+		managedLabels[name] = struct{}{}
 	}
+	update.manage(func(l string) bool {
+		_, ok := managedLabels[l]
+		return ok
+	})
 
-	if labelToAdd == "" {
-		return errMsgs
+	for _, mr := range result.ModuleResults {
+		matched, err := terraform.EvaluateCondition(label.When, mr.ParseResult(), cfg.Vars)
+		if err != nil {
+			logE.WithError(err).WithField("module", mr.Module).Error("evaluate the condition of a module label")
+			update.errMsgs = append(update.errMsgs, "evaluate the condition of a module label (module: "+mr.Module+"): "+err.Error())
+			continue
+		}
+		if !matched {
+			continue
+		}
+		name, err := label.Label.Render(mr.Module)
+		if err != nil {
+			logE.WithError(err).WithField("module", mr.Module).Error("render a module label")
+			update.errMsgs = append(update.errMsgs, "render a module label (module: "+mr.Module+"): "+err.Error())
+			continue
+		}
+		update.add(name, label.Color, label.Description)
 	}
+}
 
-	if len(labelToAdd) > 50 { //nolint:mnd
-		return append(errMsgs, fmt.Sprintf("failed to add a label %s: label name is too long (max: 50)", labelToAdd))
+// setConditionalLabel adds the label if its condition is satisfied, and removes it otherwise
+func (g *NotifyService) setConditionalLabel(update *labelUpdate, label *ConditionalLabel, result terraform.ParseResult) {
+	matched, err := terraform.EvaluateCondition(label.When, result, g.client.Config.Vars)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"program": "tfnotify",
+			"label":   label.Name,
+		}).WithError(err).Error("evaluate the condition of a label")
+		update.errMsgs = append(update.errMsgs, "evaluate the condition of a label "+label.Name+": "+err.Error())
+		return
 	}
+	update.manage(func(l string) bool {
+		return l == label.Name
+	})
+	if matched {
+		update.add(label.Name, label.Color, label.Description)
+	}
+}
 
-	if currentLabelColor == "" {
-		labels, _, err := g.client.API.IssuesAddLabels(ctx, cfg.PR.Number, []string{labelToAdd})
-		if err != nil {
-			msg := "add a label " + labelToAdd + ": " + err.Error()
-			logE.WithError(err).WithFields(logrus.Fields{
-				"label": labelToAdd,
-			}).Error("add a label")
-			errMsgs = append(errMsgs, msg)
-		}
-		if labelColor != "" {
-			// set the color of label
-			for _, label := range labels {
-				if labelToAdd == label.GetName() {
-					if label.GetColor() != labelColor {
-						if _, _, err := g.client.API.IssuesUpdateLabel(ctx, labelToAdd, labelColor); err != nil {
-							msg := "update a label color (name: " + labelToAdd + ", color: " + labelColor + "): " + err.Error()
-							logE.WithError(err).WithFields(logrus.Fields{
-								"label": labelToAdd,
-								"color": labelColor,
-							}).Error("update a label color")
-							errMsgs = append(errMsgs, msg)
-						}
-					}
-				}
+// setRiskLabel adds the label of the risk level and removes the labels of the other levels
+func (g *NotifyService) setRiskLabel(update *labelUpdate, result terraform.ParseResult) {
+	labels := g.client.Config.ResultLabels
+	if result.Risk != nil {
+		update.add(labels.RiskLabels[result.Risk.Level], labels.RiskLabelColors[result.Risk.Level], "")
+	}
+	update.manage(func(label string) bool {
+		for _, l := range labels.RiskLabels {
+			if label == l {
+				return true
 			}
 		}
-	} else if labelColor != "" && labelColor != currentLabelColor {
-		// set the color of label
-		if _, _, err := g.client.API.IssuesUpdateLabel(ctx, labelToAdd, labelColor); err != nil {
-			msg := "update a label color (name: " + labelToAdd + ", color: " + labelColor + "): " + err.Error()
-			logE.WithError(err).WithFields(logrus.Fields{
-				"label": labelToAdd,
-				"color": labelColor,
-			}).Error("update a label color")
-			errMsgs = append(errMsgs, msg)
-		}
+		return false
+	})
+}
+
+// setCostLabel adds the label if the monthly cost increases more than the threshold, and removes it otherwise
+func (g *NotifyService) setCostLabel(update *labelUpdate, result terraform.ParseResult) {
+	labels := g.client.Config.ResultLabels
+	if result.Cost != nil && result.Cost.Delta > labels.CostIncreaseThreshold {
+		update.add(labels.CostIncreaseLabel, labels.CostIncreaseLabelColor, "")
 	}
-	return errMsgs
+	update.manage(func(label string) bool {
+		return label == labels.CostIncreaseLabel
+	})
 }
 
-func (g *NotifyService) removeResultLabels(ctx context.Context, label string) (string, error) {
+// UpdateApplyLabels replaces the labels of the plan result with the label of the apply result
+func (g *NotifyService) UpdateApplyLabels(ctx context.Context, result terraform.ParseResult, exitCode int) []string {
 	cfg := g.client.Config
+	if cfg.PR.Number == 0 {
+		return nil
+	}
+	labelToAdd := cfg.ResultLabels.AppliedLabel
+	labelColor := cfg.ResultLabels.AppliedLabelColor
+	if result.HasError || exitCode != 0 {
+		labelToAdd = cfg.ResultLabels.ApplyFailedLabel
+		labelColor = cfg.ResultLabels.ApplyFailedLabelColor
+	}
+	if labelToAdd == "" {
+		// Keep the labels of the plan result if the label of the apply result is disabled
+		return nil
+	}
+	return g.updateLabel(ctx, labelToAdd, labelColor, cfg.ResultLabels.IsResultLabel)
+}
+
+// UpdateValidateLabels adds the label if the configuration is invalid, and removes it otherwise
+func (g *NotifyService) UpdateValidateLabels(ctx context.Context, result terraform.ParseResult) []string {
+	cfg := g.client.Config
+	if cfg.PR.Number == 0 {
+		return nil
+	}
+	labelToAdd := ""
+	if result.HasError {
+		labelToAdd = cfg.ValidateErrorLabel
+	}
+	return g.updateLabel(ctx, labelToAdd, cfg.ValidateErrorLabelColor, func(label string) bool {
+		return label == cfg.ValidateErrorLabel
+	})
+}
+
+// updateLabel adds labelToAdd to the pull request and removes other labels managed by tfnotify.
+// isManaged returns true if the label is managed by tfnotify.
+// If labelToAdd is empty, only managed labels are removed.
+func (g *NotifyService) updateLabel(ctx context.Context, labelToAdd, labelColor string, isManaged func(label string) bool) []string {
+	update := &labelUpdate{}
+	update.add(labelToAdd, labelColor, "")
+	update.manage(isManaged)
+	return g.applyLabelUpdate(ctx, update)
+}
+
+// applyLabelUpdate lists the labels of the pull request once, removes the managed labels which aren't added,
+// adds the labels which the pull request doesn't have at once, and sets the color and description of the added labels.
+func (g *NotifyService) applyLabelUpdate(ctx context.Context, update *labelUpdate) []string {
+	cfg := g.client.Config
+	errMsgs := update.errMsgs
+
+	logE := logrus.WithFields(logrus.Fields{
+		"program": "tfnotify",
+	})
+
 	// A Pull Request can have 100 labels the maximum
-	labels, _, err := g.client.API.IssuesListLabels(ctx, cfg.PR.Number, &github.ListOptions{
+	currentLabels, _, err := g.client.API.IssuesListLabels(ctx, cfg.PR.Number, &github.ListOptions{
 		PerPage: 100, //nolint:mnd
 	})
 	if err != nil {
-		return "", err
+		logE.WithError(err).Error("list labels")
+		return append(errMsgs, "list labels: "+err.Error())
 	}
 
-	labelColor := ""
-	for _, l := range labels {
-		labelText := l.GetName()
-		if labelText == label {
-			labelColor = l.GetColor()
+	var labelsToEdit []*github.Label
+	var labelsToAdd []string
+	for _, l := range currentLabels {
+		name := l.GetName()
+		if update.has(name) {
+			labelsToEdit = append(labelsToEdit, l)
 			continue
 		}
-		if cfg.ResultLabels.IsResultLabel(labelText) {
-			resp, err := g.client.API.IssuesRemoveLabel(ctx, cfg.PR.Number, labelText)
-			// Ignore 404 errors, which are from the PR not having the label
-			if err != nil && resp.StatusCode != http.StatusNotFound {
-				return labelColor, err
+		if !update.managed(name) {
+			continue
+		}
+		resp, err := g.client.API.IssuesRemoveLabel(ctx, cfg.PR.Number, name)
+		// Ignore 404 errors, which are from the PR not having the label
+		if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+			logE.WithError(err).WithField("label", name).Error("remove a label")
+			errMsgs = append(errMsgs, "remove a label "+name+": "+err.Error())
+		}
+	}
+	for _, l := range update.labels {
+		if !slices.ContainsFunc(currentLabels, func(current *github.Label) bool {
+			return current.GetName() == l.name
+		}) {
+			labelsToAdd = append(labelsToAdd, l.name)
+		}
+	}
+
+	if len(labelsToAdd) != 0 {
+		added, _, err := g.client.API.IssuesAddLabels(ctx, cfg.PR.Number, labelsToAdd)
+		if err != nil {
+			logE.WithError(err).WithField("labels", labelsToAdd).Error("add labels")
+			errMsgs = append(errMsgs, fmt.Sprintf("add labels %v: %s", labelsToAdd, err.Error()))
+		}
+		// IssuesAddLabels returns all labels of the pull request
+		for _, l := range added {
+			if slices.Contains(labelsToAdd, l.GetName()) {
+				labelsToEdit = append(labelsToEdit, l)
 			}
 		}
 	}
+	for _, l := range labelsToEdit {
+		label := update.get(l.GetName())
+		errMsgs = append(errMsgs, g.editLabel(ctx, l, label.color, label.description)...)
+	}
+	return errMsgs
+}
 
-	return labelColor, nil
+// editLabel sets the color and description of label if they are set and different from the current ones
+func (g *NotifyService) editLabel(ctx context.Context, label *github.Label, color, description string) []string {
+	name := label.GetName()
+	colorChanged := color != "" && color != label.GetColor()
+	descriptionChanged := description != "" && description != label.GetDescription()
+	logE := logrus.WithFields(logrus.Fields{
+		"program": "tfnotify",
+		"label":   name,
+	})
+	if !descriptionChanged {
+		if !colorChanged {
+			return nil
+		}
+		// set the color of label
+		if _, _, err := g.client.API.IssuesUpdateLabel(ctx, name, color); err != nil {
+			logE.WithError(err).WithField("color", color).Error("update a label color")
+			return []string{"update a label color (name: " + name + ", color: " + color + "): " + err.Error()}
+		}
+		return nil
+	}
+	edited := &github.Label{
+		Description: &description,
+	}
+	if colorChanged {
+		edited.Color = &color
+	}
+	if _, _, err := g.client.API.IssuesEditLabel(ctx, name, edited); err != nil {
+		logE.WithError(err).Error("update a label")
+		return []string{"update a label (name: " + name + "): " + err.Error()}
+	}
+	return nil
 }
@@ -1,8 +1,11 @@
 package github
 
 import (
+	"context"
+	"fmt"
 	"os"
 
+	"github.com/mercari/tfnotify/v1/pkg/notifier"
 	"github.com/sirupsen/logrus"
 	"github.com/suzuki-shunsuke/github-comment-metadata/metadata"
 )
@@ -11,7 +14,8 @@ import (
 // methods of GitHub API
 type NotifyService service
 
-func (g *NotifyService) getPatchedComment(logE *logrus.Entry, comments []*IssueComment, target string) *IssueComment {
+// getPatchedComment returns the latest comment of the command for the target
+func (g *NotifyService) getPatchedComment(logE *logrus.Entry, comments []*IssueComment, target, command string) *IssueComment {
 	var cmt *IssueComment
 	for i, comment := range comments {
 		logE := logE.WithFields(logrus.Fields{
@@ -32,8 +36,8 @@ func (g *NotifyService) getPatchedComment(logE *logrus.Entry, comments []*IssueC
 			logE.Debug("Program isn't tfnotify")
 			continue
 		}
-		if data.Command != "plan" {
-			logE.Debug("Command isn't plan")
+		if data.Command != command {
+			logE.WithField("command", data.Command).Debug("Command is different")
 			continue
 		}
 		if data.Target != target {
@@ -49,13 +53,74 @@ func (g *NotifyService) getPatchedComment(logE *logrus.Entry, comments []*IssueC
 	return cmt
 }
 
+// patchComment updates the latest comment of the command for the target.
+// It returns false if there is no comment to update.
+func (g *NotifyService) patchComment(ctx context.Context, logE *logrus.Entry, body, command string) (bool, error) {
+	cfg := g.client.Config
+	comments, err := g.client.Comment.List(ctx, cfg.Owner, cfg.Repo, cfg.PR.Number)
+	if err != nil {
+		return false, fmt.Errorf("list comments: %w", err)
+	}
+	logE.WithField("size", len(comments)).Debug("list comments")
+	comment := g.getPatchedComment(logE, comments, cfg.Vars["target"], command)
+	if comment == nil {
+		return false, nil
+	}
+	if comment.Body == body {
+		logE.Debug("comment isn't changed")
+		return true, nil
+	}
+	logE.WithField("comment_id", comment.DatabaseID).Debug("patch a comment")
+	if err := g.client.Comment.Patch(ctx, body, int64(comment.DatabaseID)); err != nil {
+		return false, fmt.Errorf("patch a comment: %w", err)
+	}
+	return true, nil
+}
+
+// postOrPatchComment updates the latest comment of the command if patch is enabled.
+// Otherwise, it posts a new comment unless skipPost is true.
+// skipPost is used to post a comment only when there is something to fix,
+// while keeping the previous comment up to date.
+func (g *NotifyService) postOrPatchComment(ctx context.Context, logE *logrus.Entry, body, command string, skipPost bool) error {
+	cfg := g.client.Config
+	if cfg.Patch && cfg.PR.Number != 0 {
+		logE.Debug("try patching")
+		patched, err := g.patchComment(ctx, logE, body, command)
+		if err != nil {
+			logE.WithError(err).Debug("patch a comment")
+		}
+		if patched {
+			return nil
+		}
+	}
+
+	if skipPost {
+		logE.Debug("skip posting a comment because there is nothing to fix")
+		return nil
+	}
+
+	logE.Debug("create a comment")
+	if err := g.client.Comment.Post(ctx, body, &PostOptions{
+		Number:   cfg.PR.Number,
+		Revision: cfg.PR.Revision,
+	}); err != nil {
+		return fmt.Errorf("post a comment: %w", err)
+	}
+	return nil
+}
+
 type Metadata struct {
 	Target  string
 	Program string
 	Command string
+	Parser  string
 }
 
-func getEmbeddedComment(cfg *Config, ciName string, isPlan bool) (string, error) {
+// getEmbeddedComment returns the metadata embedded in a comment.
+// command is the tfnotify command such as "plan" and "apply".
+// parser is the kind of the output detected by terraform.DetectingParser. It isn't embedded if it is empty.
+// The checksum of the plan file is embedded if the output is rendered from a plan file.
+func getEmbeddedComment(cfg *Config, param *notifier.ParamExec, command, parser string) (string, error) {
 	vars := make(map[string]any, len(cfg.EmbeddedVarNames))
 	for _, name := range cfg.EmbeddedVarNames {
 		vars[name] = cfg.Vars[name]
@@ -70,12 +135,14 @@ func getEmbeddedComment(cfg *Config, ciName string, isPlan bool) (string, error)
 	if target := cfg.Vars["target"]; target != "" {
 		data["Target"] = target
 	}
-	if isPlan {
-		data["Command"] = "plan"
-	} else {
-		data["Command"] = "apply"
+	data["Command"] = command
+	if parser != "" {
+		data["Parser"] = parser
+	}
+	if param.PlanFileChecksum != "" {
+		data["PlanFileSHA256"] = param.PlanFileChecksum
 	}
-	if err := metadata.SetCIEnv(ciName, os.Getenv, data); err != nil {
+	if err := metadata.SetCIEnv(param.CIName, os.Getenv, data); err != nil {
 		return "", err
 	}
 	embeddedComment, err := metadata.Convert(data)
@@ -1,8 +1,13 @@
 package github
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v74/github"
 	"github.com/mercari/tfnotify/v1/pkg/notifier"
 	"github.com/mercari/tfnotify/v1/pkg/terraform"
 )
@@ -277,3 +282,850 @@ func TestNotifyPlan(t *testing.T) { //nolint:tparallel
 		})
 	}
 }
+
+func TestNotifyValidate(t *testing.T) { //nolint:tparallel
+	t.Setenv("GITHUB_TOKEN", "xxx")
+	testCases := []struct {
+		name       string
+		paramExec  notifier.ParamExec
+		posted     bool
+		labelToAdd string
+	}{
+		{
+			name: "valid",
+			paramExec: notifier.ParamExec{
+				CombinedOutput: `{"format_version":"1.0","valid":true,"error_count":0,"warning_count":0,"diagnostics":[]}`,
+				ExitCode:       0,
+			},
+			posted: false,
+		},
+		{
+			name: "warning",
+			paramExec: notifier.ParamExec{
+				CombinedOutput: `{"format_version":"1.0","valid":true,"error_count":0,"warning_count":1,"diagnostics":[{"severity":"warning","summary":"Deprecated attribute","detail":""}]}`,
+				ExitCode:       0,
+			},
+			posted: true,
+		},
+		{
+			name: "invalid",
+			paramExec: notifier.ParamExec{
+				CombinedOutput: `{"format_version":"1.0","valid":false,"error_count":1,"warning_count":0,"diagnostics":[{"severity":"error","summary":"Unsupported argument","detail":""}]}`,
+				ExitCode:       1,
+			},
+			posted:     true,
+			labelToAdd: "validate-error",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := Config{
+				Owner: "owner",
+				Repo:  "repo",
+				PR: PullRequest{
+					Revision: "",
+					Number:   1,
+				},
+				Parser:             terraform.NewValidateParser(),
+				Template:           terraform.NewValidateTemplate(terraform.DefaultValidateTemplate),
+				ParseErrorTemplate: terraform.NewValidateParseErrorTemplate(terraform.DefaultValidateParseErrorTemplate),
+				ValidateErrorLabel: "validate-error",
+			}
+			client, err := NewClient(t.Context(), &cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			api := newFakeAPI()
+			posted := false
+			api.FakeIssuesCreateComment = func(ctx context.Context, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+				posted = true
+				return comment, nil, nil
+			}
+			labelToAdd := ""
+			api.FakeIssuesAddLabels = func(ctx context.Context, number int, labels []string) ([]*github.Label, *github.Response, error) {
+				labelToAdd = labels[0]
+				return nil, nil, nil
+			}
+			client.API = &api
+			paramExec := testCase.paramExec
+			if err := client.Notify.Validate(t.Context(), &paramExec); err != nil {
+				t.Fatal(err)
+			}
+			if posted != testCase.posted {
+				t.Errorf("posted: wanted %v, got %v", testCase.posted, posted)
+			}
+			if labelToAdd != testCase.labelToAdd {
+				t.Errorf("label: wanted %q, got %q", testCase.labelToAdd, labelToAdd)
+			}
+		})
+	}
+}
+
+func TestNotifyFmt(t *testing.T) { //nolint:tparallel
+	t.Setenv("GITHUB_TOKEN", "xxx")
+	testCases := []struct {
+		name      string
+		paramExec notifier.ParamExec
+		posted    bool
+		contains  string
+	}{
+		{
+			name: "formatted",
+			paramExec: notifier.ParamExec{
+				CombinedOutput: "",
+				ExitCode:       0,
+			},
+			posted: false,
+		},
+		{
+			name: "unformatted",
+			paramExec: notifier.ParamExec{
+				CombinedOutput: "main.tf\n--- old/main.tf\n+++ new/main.tf\n@@ -1 +1 @@\n-a  = 1\n+a = 1\n",
+				ExitCode:       3,
+			},
+			posted: true,
+		},
+		{
+			name: "unknown failure",
+			paramExec: notifier.ParamExec{
+				CombinedOutput: "Usage: terraform [global options] fmt [options] [target...]\n",
+				ExitCode:       1,
+			},
+			posted:   true,
+			contains: ":x: Format Check Failed",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := Config{
+				Owner: "owner",
+				Repo:  "repo",
+				PR: PullRequest{
+					Revision: "",
+					Number:   1,
+				},
+				Parser:             terraform.NewFmtParser(),
+				Template:           terraform.NewFmtTemplate(terraform.DefaultFmtTemplate),
+				ParseErrorTemplate: terraform.NewFmtParseErrorTemplate(terraform.DefaultFmtParseErrorTemplate),
+			}
+			client, err := NewClient(t.Context(), &cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			api := newFakeAPI()
+			posted := false
+			body := ""
+			api.FakeIssuesCreateComment = func(ctx context.Context, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+				posted = true
+				body = comment.GetBody()
+				return comment, nil, nil
+			}
+			client.API = &api
+			paramExec := testCase.paramExec
+			if err := client.Notify.Fmt(t.Context(), &paramExec); err != nil {
+				t.Fatal(err)
+			}
+			if posted != testCase.posted {
+				t.Errorf("posted: wanted %v, got %v", testCase.posted, posted)
+			}
+			if !strings.Contains(body, testCase.contains) {
+				t.Errorf("the comment must contain %q: %s", testCase.contains, body)
+			}
+		})
+	}
+}
+
+func TestNotifyTest(t *testing.T) { //nolint:tparallel
+	t.Setenv("GITHUB_TOKEN", "xxx")
+	testCases := []struct {
+		name      string
+		paramExec notifier.ParamExec
+		contains  []string
+		excludes  []string
+	}{
+		{
+			name: "success",
+			paramExec: notifier.ParamExec{
+				CombinedOutput: "main.tftest.hcl... in progress\n  run \"a\"... pass\nmain.tftest.hcl... tearing down\nmain.tftest.hcl... pass\n\nSuccess! 1 passed, 0 failed.\n",
+				ExitCode:       0,
+			},
+			contains: []string{
+				"## :white_check_mark: Test Succeeded",
+				"| <code>main.tftest.hcl</code> | a | :white_check_mark: pass |",
+			},
+			excludes: []string{
+				":x:",
+			},
+		},
+		{
+			name: "failure",
+			paramExec: notifier.ParamExec{
+				CombinedOutput: `main.tftest.hcl... in progress
+  run "a"... pass
+  run "b"... fail
+╷
+│ Error: Test assertion failed
+│
+│   on main.tftest.hcl line 12, in run "b":
+│   12:     condition     = output.name == "foo"
+│
+│ The name is wrong.
+╵
+main.tftest.hcl... tearing down
+main.tftest.hcl... fail
+
+Failure! 1 passed, 1 failed.
+`,
+				ExitCode: 1,
+			},
+			contains: []string{
+				"## :x: Test Failed",
+				"| <code>main.tftest.hcl</code> | a | :white_check_mark: pass |",
+				"| <code>main.tftest.hcl</code> | b | :x: fail |",
+				`### :x: <code>main.tftest.hcl</code> run "b"`,
+				"Test assertion failed",
+				"The name is wrong.",
+			},
+			excludes: []string{
+				"Test Succeeded",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := Config{
+				Owner: "owner",
+				Repo:  "repo",
+				PR: PullRequest{
+					Revision: "",
+					Number:   1,
+				},
+				Parser:             terraform.NewTestParser(),
+				Template:           terraform.NewTestTemplate(terraform.DefaultTestTemplate),
+				ParseErrorTemplate: terraform.NewTestParseErrorTemplate(terraform.DefaultTestParseErrorTemplate),
+			}
+			client, err := NewClient(t.Context(), &cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			api := newFakeAPI()
+			body := ""
+			api.FakeIssuesCreateComment = func(ctx context.Context, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+				body = comment.GetBody()
+				return comment, nil, nil
+			}
+			client.API = &api
+			paramExec := testCase.paramExec
+			if err := client.Notify.Test(t.Context(), &paramExec); err != nil {
+				t.Fatal(err)
+			}
+			if body == "" {
+				t.Fatal("a comment must be posted")
+			}
+			for _, s := range testCase.contains {
+				if !strings.Contains(body, s) {
+					t.Errorf("the comment must contain %q:\n%s", s, body)
+				}
+			}
+			for _, s := range testCase.excludes {
+				if strings.Contains(body, s) {
+					t.Errorf("the comment must not contain %q:\n%s", s, body)
+				}
+			}
+		})
+	}
+}
+
+func TestNotifyDrift(t *testing.T) { //nolint:tparallel
+	t.Setenv("GITHUB_TOKEN", "xxx")
+	const driftOutput = `Note: Objects have changed outside of Terraform
+
+Terraform detected the following changes made outside of Terraform since the
+last "terraform apply" which may have affected this plan:
+
+  # null_resource.foo has been deleted
+  - resource "null_resource" "foo" {
+      - id = "123" -> null
+    }
+
+This is a refresh-only plan, so Terraform will not take any actions to undo
+these. If you were expecting these changes then you can apply this plan to
+record the updated values in the Terraform state without changing any remote
+objects.
+`
+	const noDriftOutput = `No changes. Your infrastructure still matches the configuration.
+`
+	const errorOutput = `Planning failed. Terraform encountered an error while generating this plan.
+
+╷
+│ Error: No valid credential sources found
+│
+│ Please see https://registry.terraform.io/providers/hashicorp/aws
+╵
+`
+	existingIssue := &github.Issue{
+		Number: github.Ptr(3),
+		Body:   github.Ptr("old body\n<!-- github-comment: {\"Command\":\"drift\",\"Program\":\"tfnotify\"} -->"),
+	}
+	testCases := []struct {
+		name      string
+		paramExec notifier.ParamExec
+		issues    []*github.Issue
+		unchanged bool
+		created   bool
+		updated   bool
+		closed    bool
+		isErr     bool
+	}{
+		{
+			name: "no drift and no issue",
+			paramExec: notifier.ParamExec{
+				CombinedOutput: noDriftOutput,
+				ExitCode:       0,
+			},
+		},
+		{
+			name: "drift and no issue",
+			paramExec: notifier.ParamExec{
+				CombinedOutput: driftOutput,
+				ExitCode:       2,
+			},
+			created: true,
+		},
+		{
+			name: "drift and existing issue",
+			paramExec: notifier.ParamExec{
+				CombinedOutput: driftOutput,
+				ExitCode:       2,
+			},
+			issues:  []*github.Issue{existingIssue},
+			updated: true,
+		},
+		{
+			name: "no drift and existing issue",
+			paramExec: notifier.ParamExec{
+				CombinedOutput: noDriftOutput,
+				ExitCode:       0,
+			},
+			issues: []*github.Issue{existingIssue},
+			closed: true,
+		},
+		{
+			name: "drift and unchanged issue with different metadata",
+			paramExec: notifier.ParamExec{
+				CombinedOutput: driftOutput,
+				ExitCode:       2,
+			},
+			unchanged: true,
+		},
+		{
+			name: "plan failed and existing issue",
+			paramExec: notifier.ParamExec{
+				CombinedOutput: errorOutput,
+				ExitCode:       1,
+			},
+			issues: []*github.Issue{existingIssue},
+			isErr:  true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := Config{
+				Owner:              "owner",
+				Repo:               "repo",
+				Parser:             terraform.NewDriftParser(),
+				Template:           terraform.NewDriftTemplate(terraform.DefaultDriftTemplate),
+				ParseErrorTemplate: terraform.NewDriftParseErrorTemplate(terraform.DefaultDriftParseErrorTemplate),
+				DriftIssue: DriftIssue{
+					Title:  "Drift detected",
+					Labels: []string{"drift"},
+				},
+			}
+			client, err := NewClient(t.Context(), &cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			api := newFakeAPI()
+			created := false
+			updated := false
+			closed := false
+			issues := testCase.issues
+			createdBody := ""
+			api.FakeIssuesListByRepo = func(ctx context.Context, opt *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error) {
+				return issues, nil, nil
+			}
+			api.FakeIssuesCreate = func(ctx context.Context, issue *github.IssueRequest) (*github.Issue, *github.Response, error) {
+				created = true
+				createdBody = issue.GetBody()
+				return &github.Issue{Number: github.Ptr(10)}, nil, nil
+			}
+			api.FakeIssuesEdit = func(ctx context.Context, number int, issue *github.IssueRequest) (*github.Issue, *github.Response, error) {
+				if issue.GetState() == "closed" {
+					closed = true
+				} else {
+					updated = true
+				}
+				return &github.Issue{Number: github.Ptr(number)}, nil, nil
+			}
+			client.API = &api
+			paramExec := testCase.paramExec
+			if testCase.unchanged {
+				// the issue created by another CI run has the same body and different metadata
+				if err := client.Notify.Drift(t.Context(), &paramExec); err != nil {
+					t.Fatal(err)
+				}
+				issues = []*github.Issue{{
+					Number: github.Ptr(3),
+					Body:   github.Ptr(removeEmbeddedComment(createdBody) + "\n<!-- github-comment: {\"Command\":\"drift\",\"JobID\":\"old\",\"Program\":\"tfnotify\"} -->"),
+				}}
+				created = false
+			}
+			err = client.Notify.Drift(t.Context(), &paramExec)
+			if err != nil {
+				if !testCase.isErr {
+					t.Fatal(err)
+				}
+			} else if testCase.isErr {
+				t.Fatal("error must be returned")
+			}
+			if created != testCase.created {
+				t.Errorf("created: wanted %v, got %v", testCase.created, created)
+			}
+			if updated != testCase.updated {
+				t.Errorf("updated: wanted %v, got %v", testCase.updated, updated)
+			}
+			if closed != testCase.closed {
+				t.Errorf("closed: wanted %v, got %v", testCase.closed, closed)
+			}
+		})
+	}
+}
+
+func TestGetEmbeddedComment(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{
+		PR: PullRequest{
+			Revision: "abc",
+			Number:   1,
+		},
+	}
+	comment, err := getEmbeddedComment(cfg, &notifier.ParamExec{PlanFileChecksum: "0123"}, "plan", terraform.ParserKindTerraform)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{`"Command":"plan"`, `"Parser":"terraform"`, `"PlanFileSHA256":"0123"`, `"SHA1":"abc"`} {
+		if !strings.Contains(comment, s) {
+			t.Errorf("%s isn't embedded: %s", s, comment)
+		}
+	}
+	comment, err = getEmbeddedComment(cfg, &notifier.ParamExec{}, "plan", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(comment, "PlanFileSHA256") {
+		t.Errorf("PlanFileSHA256 must not be embedded without a plan file: %s", comment)
+	}
+}
+
+func TestUpdateLabelsCostIncrease(t *testing.T) { //nolint:tparallel
+	t.Setenv("GITHUB_TOKEN", "xxx")
+	testCases := []struct {
+		name  string
+		cost  *terraform.Cost
+		added []string
+	}{
+		{
+			name:  "increase more than the threshold",
+			cost:  &terraform.Cost{Delta: 10.5},
+			added: []string{"add-or-update", "cost-increase"},
+		},
+		{
+			name:  "increase less than the threshold",
+			cost:  &terraform.Cost{Delta: 10},
+			added: []string{"add-or-update"},
+		},
+		{
+			name:  "no cost",
+			added: []string{"add-or-update"},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			client, err := NewClient(t.Context(), &Config{
+				Owner: "owner",
+				Repo:  "repo",
+				PR: PullRequest{
+					Number: 1,
+				},
+				ResultLabels: ResultLabels{
+					AddOrUpdateLabel:      "add-or-update",
+					CostIncreaseLabel:     "cost-increase",
+					CostIncreaseThreshold: 10,
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			api := newFakeAPI()
+			var added []string
+			api.FakeIssuesAddLabels = func(ctx context.Context, number int, labels []string) ([]*github.Label, *github.Response, error) {
+				added = append(added, labels...)
+				return nil, nil, nil
+			}
+			client.API = &api
+			if errMsgs := client.Notify.UpdateLabels(t.Context(), terraform.ParseResult{
+				HasAddOrUpdateOnly: true,
+				Cost:               testCase.cost,
+			}); len(errMsgs) != 0 {
+				t.Fatal(errMsgs)
+			}
+			if diff := cmp.Diff(added, testCase.added); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestUpdateLabelsListOnce(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "xxx")
+	client, err := NewClient(t.Context(), &Config{
+		Owner: "owner",
+		Repo:  "repo",
+		PR: PullRequest{
+			Number: 1,
+		},
+		ResultLabels: ResultLabels{
+			AddOrUpdateLabel:  "add-or-update",
+			DestroyLabel:      "destroy",
+			CostIncreaseLabel: "cost-increase",
+			RiskLabels: map[string]string{
+				"high":     "risk-high",
+				"critical": "risk-critical",
+			},
+			ProtectedLabel: "destroy-protected",
+			ConditionalLabels: []*ConditionalLabel{
+				{
+					Name: "many-destroys",
+					When: "gt .DestroyCount 1",
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := newFakeAPI()
+	listed := 0
+	api.FakeIssuesListLabels = func(ctx context.Context, number int, opts *github.ListOptions) ([]*github.Label, *github.Response, error) {
+		listed++
+		return []*github.Label{
+			{Name: github.Ptr("add-or-update")},
+			{Name: github.Ptr("risk-high")},
+			{Name: github.Ptr("many-destroys")},
+			{Name: github.Ptr("other")},
+		}, nil, nil
+	}
+	var added [][]string
+	var removed []string
+	api.FakeIssuesAddLabels = func(ctx context.Context, number int, labels []string) ([]*github.Label, *github.Response, error) {
+		added = append(added, labels)
+		return nil, nil, nil
+	}
+	api.FakeIssuesRemoveLabel = func(ctx context.Context, number int, label string) (*github.Response, error) {
+		removed = append(removed, label)
+		return nil, nil
+	}
+	client.API = &api
+	if errMsgs := client.Notify.UpdateLabels(t.Context(), terraform.ParseResult{
+		HasDestroy:         true,
+		DestroyCount:       1,
+		Risk:               &terraform.Risk{Level: "critical"},
+		ProtectedResources: []string{"aws_kms_key.main"},
+	}); len(errMsgs) != 0 {
+		t.Fatal(errMsgs)
+	}
+	if listed != 1 {
+		t.Errorf("labels must be listed once, but listed %d times", listed)
+	}
+	if diff := cmp.Diff(added, [][]string{{"destroy", "risk-critical", "destroy-protected"}}); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff(removed, []string{"add-or-update", "risk-high", "many-destroys"}); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestUpdateLabelsConditionalLabels(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "xxx")
+	client, err := NewClient(t.Context(), &Config{
+		Owner: "owner",
+		Repo:  "repo",
+		PR: PullRequest{
+			Number: 1,
+		},
+		ResultLabels: ResultLabels{
+			ConditionalLabels: []*ConditionalLabel{
+				{
+					Name:        "iam-change",
+					Color:       "fbca04",
+					Description: "IAM resources are changed",
+					When:        `.HasResourceType "aws_iam_*"`,
+				},
+				{
+					Name: "destroy",
+					When: "gt .DestroyCount 0",
+				},
+				{
+					Name: "invalid",
+					When: "gt .DestroyCount",
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := newFakeAPI()
+	api.FakeIssuesListLabels = func(ctx context.Context, number int, opts *github.ListOptions) ([]*github.Label, *github.Response, error) {
+		return []*github.Label{
+			{Name: github.Ptr("destroy")},
+			{Name: github.Ptr("invalid")},
+		}, nil, nil
+	}
+	var added, removed []string
+	api.FakeIssuesAddLabels = func(ctx context.Context, number int, labels []string) ([]*github.Label, *github.Response, error) {
+		added = append(added, labels...)
+		ret := make([]*github.Label, len(labels))
+		for i, label := range labels {
+			ret[i] = &github.Label{Name: github.Ptr(label)}
+		}
+		return ret, nil, nil
+	}
+	api.FakeIssuesRemoveLabel = func(ctx context.Context, number int, label string) (*github.Response, error) {
+		removed = append(removed, label)
+		return nil, nil
+	}
+	var edited []*github.Label
+	api.FakeIssuesEditLabel = func(ctx context.Context, name string, label *github.Label) (*github.Label, *github.Response, error) {
+		edited = append(edited, label)
+		return label, nil, nil
+	}
+	client.API = &api
+	errMsgs := client.Notify.UpdateLabels(t.Context(), terraform.ParseResult{
+		HasAddOrUpdateOnly: true,
+		AddCount:           1,
+		CreatedAddresses:   []*terraform.ResourceAddress{terraform.ParseResourceAddress("aws_iam_role.foo")},
+	})
+	if len(errMsgs) != 1 {
+		t.Errorf("the invalid condition must be reported: %v", errMsgs)
+	}
+	if diff := cmp.Diff(added, []string{"iam-change"}); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff(removed, []string{"destroy"}); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff(edited, []*github.Label{{Color: github.Ptr("fbca04"), Description: github.Ptr("IAM resources are changed")}}); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestUpdateLabelsModuleLabels(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "xxx")
+	moduleLabel, err := terraform.NewModuleLabel("{{.Module}}/destroy", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := NewClient(t.Context(), &Config{
+		Owner: "owner",
+		Repo:  "repo",
+		PR: PullRequest{
+			Number: 1,
+		},
+		Vars: map[string]string{
+			"target": "prod",
+		},
+		ResultLabels: ResultLabels{
+			DestroyLabel: "prod/destroy",
+			ModuleLabels: []*ModuleLabel{
+				{
+					Label: moduleLabel,
+					When:  ".HasDestroy",
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := newFakeAPI()
+	api.FakeIssuesListLabels = func(ctx context.Context, number int, opts *github.ListOptions) ([]*github.Label, *github.Response, error) {
+		return []*github.Label{
+			{Name: github.Ptr("prod/db/destroy")},
+			{Name: github.Ptr("prod/vpc/destroy")},
+			{Name: github.Ptr("destroy")},
+			// the label of the plan result of this target
+			{Name: github.Ptr("prod/destroy")},
+			// the module label of another target
+			{Name: github.Ptr("dev/app/destroy")},
+		}, nil, nil
+	}
+	var added, removed []string
+	api.FakeIssuesAddLabels = func(ctx context.Context, number int, labels []string) ([]*github.Label, *github.Response, error) {
+		added = append(added, labels...)
+		return nil, nil, nil
+	}
+	api.FakeIssuesRemoveLabel = func(ctx context.Context, number int, label string) (*github.Response, error) {
+		removed = append(removed, label)
+		return nil, nil
+	}
+	client.API = &api
+	if errMsgs := client.Notify.UpdateLabels(t.Context(), terraform.ParseResult{
+		HasDestroy: true,
+		Modules:    []string{"prod/vpc", "prod/app", "prod/iam", "prod/db"},
+		ModuleResults: []*terraform.ModuleResult{
+			{
+				Module:           "prod/vpc",
+				DeletedResources: []string{"aws_subnet.a"},
+			},
+			{
+				Module:            "prod/app",
+				ReplacedResources: []string{"aws_instance.web"},
+			},
+			{
+				Module:           "prod/iam",
+				CreatedResources: []string{"aws_iam_role.foo"},
+			},
+		},
+	}); len(errMsgs) != 0 {
+		t.Fatal(errMsgs)
+	}
+	if diff := cmp.Diff(added, []string{"prod/app/destroy"}); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff(removed, []string{"prod/db/destroy"}); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestUpdateApplyLabels(t *testing.T) { //nolint:tparallel
+	t.Setenv("GITHUB_TOKEN", "xxx")
+	testCases := []struct {
+		name     string
+		result   terraform.ParseResult
+		exitCode int
+		labels   ResultLabels
+		added    []string
+		removed  []string
+	}{
+		{
+			name: "success",
+			labels: ResultLabels{
+				AddOrUpdateLabel: "add-or-update",
+				AppliedLabel:     "applied",
+				ApplyFailedLabel: "apply-failed",
+			},
+			added:   []string{"applied"},
+			removed: []string{"add-or-update"},
+		},
+		{
+			name:     "failure",
+			exitCode: 1,
+			labels: ResultLabels{
+				AddOrUpdateLabel: "add-or-update",
+				AppliedLabel:     "applied",
+				ApplyFailedLabel: "apply-failed",
+			},
+			added:   []string{"apply-failed"},
+			removed: []string{"add-or-update"},
+		},
+		{
+			name:   "the label of failure is disabled",
+			result: terraform.ParseResult{HasError: true},
+			labels: ResultLabels{
+				AddOrUpdateLabel: "add-or-update",
+				AppliedLabel:     "applied",
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			client, err := NewClient(t.Context(), &Config{
+				Owner: "owner",
+				Repo:  "repo",
+				PR: PullRequest{
+					Number: 1,
+				},
+				ResultLabels: testCase.labels,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			api := newFakeAPI()
+			api.FakeIssuesListLabels = func(ctx context.Context, number int, opts *github.ListOptions) ([]*github.Label, *github.Response, error) {
+				return []*github.Label{
+					{Name: github.Ptr("add-or-update")},
+					{Name: github.Ptr("other")},
+				}, nil, nil
+			}
+			var added, removed []string
+			api.FakeIssuesAddLabels = func(ctx context.Context, number int, labels []string) ([]*github.Label, *github.Response, error) {
+				added = append(added, labels...)
+				return nil, nil, nil
+			}
+			api.FakeIssuesRemoveLabel = func(ctx context.Context, number int, label string) (*github.Response, error) {
+				removed = append(removed, label)
+				return nil, nil
+			}
+			client.API = &api
+			if errMsgs := client.Notify.UpdateApplyLabels(t.Context(), testCase.result, testCase.exitCode); len(errMsgs) != 0 {
+				t.Fatal(errMsgs)
+			}
+			if diff := cmp.Diff(added, testCase.added); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(removed, testCase.removed); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestUpdateValidateLabelsRemoveError(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "xxx")
+	client, err := NewClient(t.Context(), &Config{
+		Owner: "owner",
+		Repo:  "repo",
+		PR: PullRequest{
+			Number: 1,
+		},
+		ValidateErrorLabel: "validate-error",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := newFakeAPI()
+	api.FakeIssuesListLabels = func(ctx context.Context, number int, opts *github.ListOptions) ([]*github.Label, *github.Response, error) {
+		return []*github.Label{
+			{Name: github.Ptr("validate-error")},
+		}, nil, nil
+	}
+	api.FakeIssuesRemoveLabel = func(ctx context.Context, number int, label string) (*github.Response, error) {
+		// a transport error has no response
+		return nil, errors.New("connection reset")
+	}
+	client.API = &api
+	if errMsgs := client.Notify.UpdateValidateLabels(t.Context(), terraform.ParseResult{}); len(errMsgs) == 0 {
+		t.Error("the error of removing a label must be returned")
+	}
+}
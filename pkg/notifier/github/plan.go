@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/mercari/tfnotify/v1/pkg/mask"
 	"github.com/mercari/tfnotify/v1/pkg/notifier"
@@ -24,6 +25,7 @@ func (g *NotifyService) Plan(ctx context.Context, param *notifier.ParamExec) err
 	}
 
 	result := parser.Parse(param.CombinedOutput)
+	result.Cost = param.Cost
 	if result.HasParseError {
 		template = g.client.Config.ParseErrorTemplate
 	} else {
@@ -41,6 +43,10 @@ func (g *NotifyService) Plan(ctx context.Context, param *notifier.ParamExec) err
 
 	if cfg.IgnoreWarning {
 		result.Warning = ""
+		// The result may be shared with other notifiers, so the diagnostics are cloned before deleting warnings
+		result.Diagnostics = slices.DeleteFunc(slices.Clone(result.Diagnostics), func(diag *terraform.Diagnostic) bool {
+			return diag.Severity == terraform.DiagnosticSeverityWarning
+		})
 	}
 
 	// Generate AI summary if summarizer is provided
@@ -64,6 +70,8 @@ func (g *NotifyService) Plan(ctx context.Context, param *notifier.ParamExec) err
 			"HasError":               result.HasError,
 			"Warning":                result.Warning,
 			"ChangeOutsideTerraform": result.OutsideTerraform,
+			"Diagnostics":            result.Diagnostics,
+			"OutputChanges":          result.OutputChanges,
 			"ErrorMessages":          errMsgs,
 			"ExitCode":               param.ExitCode,
 			"CombinedOutput":         param.CombinedOutput,
@@ -101,6 +109,12 @@ func (g *NotifyService) Plan(ctx context.Context, param *notifier.ParamExec) err
 		Warning:                result.Warning,
 		HasDestroy:             result.HasDestroy,
 		HasError:               result.HasError,
+		ImportCount:            result.ImportCount,
+		AddCount:               result.AddCount,
+		ChangeCount:            result.ChangeCount,
+		DestroyCount:           result.DestroyCount,
+		MoveCount:              result.MoveCount,
+		ForgetCount:            result.ForgetCount,
 		Link:                   cfg.CI,
 		UseRawOutput:           cfg.UseRawOutput,
 		Vars:                   cfg.Vars,
@@ -116,9 +130,27 @@ func (g *NotifyService) Plan(ctx context.Context, param *notifier.ParamExec) err
 		ReplacedResources:      result.ReplacedResources,
 		MovedResources:         result.MovedResources,
 		ImportedResources:      result.ImportedResources,
+		ForgottenResources:     result.ForgottenResources,
+		DeferredResources:      result.DeferredResources,
+		ReadResources:          result.ReadResources,
+		CreatedAddresses:       result.CreatedAddresses,
+		UpdatedAddresses:       result.UpdatedAddresses,
+		DeletedAddresses:       result.DeletedAddresses,
+		ReplacedAddresses:      result.ReplacedAddresses,
+		ImportedAddresses:      result.ImportedAddresses,
+		ResourceChanges:        result.ResourceChanges,
+		OutputChanges:          result.OutputChanges,
+		Diagnostics:            result.Diagnostics,
+		RunURL:                 result.RunURL,
+		PolicyChecks:           result.PolicyChecks,
+		CostEstimation:         result.CostEstimation,
+		Cost:                   result.Cost,
+		Risk:                   result.Risk,
+		ProtectedResources:     result.ProtectedResources,
 		ModuleResults:          result.ModuleResults,
 		AISummary:              aiSummary,
 		SummaryEnabled:         param.AISummarizer != nil,
+		Parser:                 result.Parser,
 	})
 	body, err := template.Execute()
 	if err != nil {
@@ -129,7 +161,7 @@ func (g *NotifyService) Plan(ctx context.Context, param *notifier.ParamExec) err
 		"program": "tfnotify",
 	})
 
-	embeddedComment, err := getEmbeddedComment(cfg, param.CIName, true)
+	embeddedComment, err := getEmbeddedComment(cfg, param, "plan", result.Parser)
 	if err != nil {
 		return err
 	}
@@ -156,7 +188,7 @@ func (g *NotifyService) Plan(ctx context.Context, param *notifier.ParamExec) err
 			return nil
 		}
 		logE.WithField("size", len(comments)).Debug("list comments")
-		comment := g.getPatchedComment(logE, comments, cfg.Vars["target"])
+		comment := g.getPatchedComment(logE, comments, cfg.Vars["target"], "plan")
 		if comment != nil {
 			if comment.Body == body {
 				logE.Debug("comment isn't changed")
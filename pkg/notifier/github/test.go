@@ -0,0 +1,68 @@
+package github
+
+import (
+	"context"
+
+	"github.com/mercari/tfnotify/v1/pkg/mask"
+	"github.com/mercari/tfnotify/v1/pkg/notifier"
+	"github.com/mercari/tfnotify/v1/pkg/terraform"
+	"github.com/sirupsen/logrus"
+)
+
+// Test posts comment for terraform test.
+// Unlike fmt and validate, the comment is posted even if all tests pass, so that the results table is shown.
+func (g *NotifyService) Test(ctx context.Context, param *notifier.ParamExec) error {
+	cfg := g.client.Config
+	parser := g.client.Config.Parser
+	template := g.client.Config.Template
+
+	if cfg.PR.Number == 0 && cfg.PR.Revision != "" {
+		if prNumber, err := g.client.Commits.PRNumber(ctx, cfg.PR.Revision); err == nil {
+			cfg.PR.Number = prNumber
+		}
+	}
+
+	result := parser.Parse(param.CombinedOutput)
+	if result.HasParseError {
+		template = g.client.Config.ParseErrorTemplate
+	} else if result.Error != nil {
+		return result.Error
+	}
+
+	template.SetValue(terraform.CommonTemplate{
+		Result:         result.Result,
+		HasError:       result.HasError,
+		Link:           cfg.CI,
+		UseRawOutput:   cfg.UseRawOutput,
+		Vars:           cfg.Vars,
+		Templates:      cfg.Templates,
+		Stdout:         param.Stdout,
+		Stderr:         param.Stderr,
+		CombinedOutput: param.CombinedOutput,
+		ExitCode:       param.ExitCode,
+		Diagnostics:    result.Diagnostics,
+		TestFiles:      result.TestFiles,
+	})
+	body, err := template.Execute()
+	if err != nil {
+		return err
+	}
+
+	logE := logrus.WithFields(logrus.Fields{
+		"program": "tfnotify",
+	})
+
+	embeddedComment, err := getEmbeddedComment(cfg, param, "test", "")
+	if err != nil {
+		return err
+	}
+	logE.WithFields(logrus.Fields{
+		"comment": embeddedComment,
+	}).Debug("embedded HTML comment")
+	// embed HTML tag to hide old comments
+	body += embeddedComment
+
+	body = mask.Mask(body, g.client.Config.Masks)
+
+	return g.postOrPatchComment(ctx, logE, body, "test", false)
+}
@@ -0,0 +1,77 @@
+package github
+
+import (
+	"context"
+
+	"github.com/mercari/tfnotify/v1/pkg/mask"
+	"github.com/mercari/tfnotify/v1/pkg/notifier"
+	"github.com/mercari/tfnotify/v1/pkg/terraform"
+	"github.com/sirupsen/logrus"
+)
+
+// Validate posts comment for terraform validate.
+// A comment isn't posted if the configuration is valid without any warning,
+// but the previous comment is updated if patch is enabled.
+func (g *NotifyService) Validate(ctx context.Context, param *notifier.ParamExec) error {
+	cfg := g.client.Config
+	parser := g.client.Config.Parser
+	template := g.client.Config.Template
+	var errMsgs []string
+
+	if cfg.PR.Number == 0 && cfg.PR.Revision != "" {
+		if prNumber, err := g.client.Commits.PRNumber(ctx, cfg.PR.Revision); err == nil {
+			cfg.PR.Number = prNumber
+		}
+	}
+
+	result := parser.Parse(param.CombinedOutput)
+	if result.HasParseError {
+		template = g.client.Config.ParseErrorTemplate
+	} else if result.Error != nil {
+		return result.Error
+	}
+
+	if cfg.PR.IsNumber() && cfg.ValidateErrorLabel != "" {
+		errMsgs = append(errMsgs, g.UpdateValidateLabels(ctx, result)...)
+	}
+
+	template.SetValue(terraform.CommonTemplate{
+		Result:         result.Result,
+		Warning:        result.Warning,
+		HasError:       result.HasError,
+		Link:           cfg.CI,
+		UseRawOutput:   cfg.UseRawOutput,
+		Vars:           cfg.Vars,
+		Templates:      cfg.Templates,
+		Stdout:         param.Stdout,
+		Stderr:         param.Stderr,
+		CombinedOutput: param.CombinedOutput,
+		ExitCode:       param.ExitCode,
+		ErrorMessages:  errMsgs,
+		Diagnostics:    result.Diagnostics,
+	})
+	body, err := template.Execute()
+	if err != nil {
+		return err
+	}
+
+	logE := logrus.WithFields(logrus.Fields{
+		"program": "tfnotify",
+	})
+
+	embeddedComment, err := getEmbeddedComment(cfg, param, "validate", "")
+	if err != nil {
+		return err
+	}
+	logE.WithFields(logrus.Fields{
+		"comment": embeddedComment,
+	}).Debug("embedded HTML comment")
+	// embed HTML tag to hide old comments
+	body += embeddedComment
+
+	body = mask.Mask(body, g.client.Config.Masks)
+
+	isValid := !result.HasParseError && !result.HasError && param.ExitCode == 0
+	skipPost := isValid && len(result.Diagnostics) == 0 && len(errMsgs) == 0
+	return g.postOrPatchComment(ctx, logE, body, "validate", skipPost)
+}
@@ -51,6 +51,8 @@ func (g *NotifyService) Apply(ctx context.Context, param *notifier.ParamExec) er
 			"HasError":               result.HasError,
 			"Warning":                result.Warning,
 			"ChangeOutsideTerraform": result.OutsideTerraform,
+			"Diagnostics":            result.Diagnostics,
+			"AppliedResources":       result.AppliedResources,
 			"ErrorMessages":          errMsgs,
 			"ExitCode":               param.ExitCode,
 			"CombinedOutput":         param.CombinedOutput,
@@ -87,6 +89,12 @@ func (g *NotifyService) Apply(ctx context.Context, param *notifier.ParamExec) er
 		Warning:                result.Warning,
 		HasDestroy:             result.HasDestroy,
 		HasError:               result.HasError,
+		ImportCount:            result.ImportCount,
+		AddCount:               result.AddCount,
+		ChangeCount:            result.ChangeCount,
+		DestroyCount:           result.DestroyCount,
+		MoveCount:              result.MoveCount,
+		ForgetCount:            result.ForgetCount,
 		Link:                   cfg.CI,
 		UseRawOutput:           cfg.UseRawOutput,
 		Vars:                   cfg.Vars,
@@ -100,9 +108,15 @@ func (g *NotifyService) Apply(ctx context.Context, param *notifier.ParamExec) er
 		UpdatedResources:       result.UpdatedResources,
 		DeletedResources:       result.DeletedResources,
 		ReplacedResources:      result.ReplacedResources,
+		Diagnostics:            result.Diagnostics,
+		RunURL:                 result.RunURL,
+		PolicyChecks:           result.PolicyChecks,
+		CostEstimation:         result.CostEstimation,
+		AppliedResources:       result.AppliedResources,
 		ModuleResults:          result.ModuleResults,
 		AISummary:              aiSummary,
 		SummaryEnabled:         param.AISummarizer != nil,
+		Parser:                 result.Parser,
 	})
 	body, err := template.Execute()
 	if err != nil {
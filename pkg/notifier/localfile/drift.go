@@ -0,0 +1,12 @@
+package localfile
+
+import (
+	"context"
+
+	"github.com/mercari/tfnotify/v1/pkg/notifier"
+)
+
+// Drift writes the result of drift detection to a file
+func (g *NotifyService) Drift(_ context.Context, param *notifier.ParamExec) error {
+	return g.writeResult("drift", param, g.client.Config.Parser.Parse(param.CombinedOutput))
+}
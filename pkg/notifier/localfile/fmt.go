@@ -0,0 +1,13 @@
+package localfile
+
+import (
+	"context"
+
+	"github.com/mercari/tfnotify/v1/pkg/notifier"
+	"github.com/mercari/tfnotify/v1/pkg/terraform"
+)
+
+// Fmt writes the result of terraform fmt -check to a file
+func (g *NotifyService) Fmt(_ context.Context, param *notifier.ParamExec) error {
+	return g.writeResult("fmt", param, terraform.ParseFmt(g.client.Config.Parser, param.CombinedOutput, param.ExitCode))
+}
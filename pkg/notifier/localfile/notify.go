@@ -1,5 +1,58 @@
 package localfile
 
+import (
+	"fmt"
+
+	"github.com/mercari/tfnotify/v1/pkg/mask"
+	"github.com/mercari/tfnotify/v1/pkg/notifier"
+	"github.com/mercari/tfnotify/v1/pkg/terraform"
+	"github.com/sirupsen/logrus"
+)
+
 // NotifyService handles communication with the notification related
 // methods of GitHub API
 type NotifyService service
+
+// writeResult writes the result of validate, fmt, test, or drift to a file
+func (g *NotifyService) writeResult(command string, param *notifier.ParamExec, result terraform.ParseResult) error {
+	cfg := g.client.Config
+	template := g.client.Config.Template
+	if result.HasParseError {
+		template = g.client.Config.ParseErrorTemplate
+	} else if result.Error != nil {
+		return result.Error
+	}
+
+	template.SetValue(terraform.CommonTemplate{
+		Result:                 result.Result,
+		ChangeOutsideTerraform: result.OutsideTerraform,
+		Warning:                result.Warning,
+		HasError:               result.HasError,
+		Link:                   cfg.CI,
+		UseRawOutput:           cfg.UseRawOutput,
+		Vars:                   cfg.Vars,
+		Templates:              cfg.Templates,
+		Stdout:                 param.Stdout,
+		Stderr:                 param.Stderr,
+		CombinedOutput:         param.CombinedOutput,
+		ExitCode:               param.ExitCode,
+		Diagnostics:            result.Diagnostics,
+		UnformattedFiles:       result.UnformattedFiles,
+		DriftedResources:       result.DriftedResources,
+		TestFiles:              result.TestFiles,
+	})
+	body, err := template.Execute()
+	if err != nil {
+		return err
+	}
+
+	body = mask.Mask(body, g.client.Config.Masks)
+
+	logrus.WithFields(logrus.Fields{
+		"program": "tfnotify",
+	}).Debug("write a " + command + " output to a file")
+	if err := g.client.Output.WriteToFile(body, cfg.OutputFile); err != nil {
+		return fmt.Errorf("write a %s output to a file: %w", command, err)
+	}
+	return nil
+}
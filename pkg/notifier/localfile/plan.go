@@ -18,6 +18,7 @@ func (g *NotifyService) Plan(ctx context.Context, param *notifier.ParamExec) err
 	var errMsgs []string
 
 	result := parser.Parse(param.CombinedOutput)
+	result.Cost = param.Cost
 	if result.HasParseError {
 		template = g.client.Config.ParseErrorTemplate
 	} else {
@@ -58,6 +59,8 @@ func (g *NotifyService) Plan(ctx context.Context, param *notifier.ParamExec) err
 			"HasError":               result.HasError,
 			"Warning":                result.Warning,
 			"ChangeOutsideTerraform": result.OutsideTerraform,
+			"Diagnostics":            result.Diagnostics,
+			"OutputChanges":          result.OutputChanges,
 			"ErrorMessages":          errMsgs,
 			"ExitCode":               param.ExitCode,
 			"CombinedOutput":         param.CombinedOutput,
@@ -94,6 +97,12 @@ func (g *NotifyService) Plan(ctx context.Context, param *notifier.ParamExec) err
 		Warning:                result.Warning,
 		HasDestroy:             result.HasDestroy,
 		HasError:               result.HasError,
+		ImportCount:            result.ImportCount,
+		AddCount:               result.AddCount,
+		ChangeCount:            result.ChangeCount,
+		DestroyCount:           result.DestroyCount,
+		MoveCount:              result.MoveCount,
+		ForgetCount:            result.ForgetCount,
 		Link:                   cfg.CI,
 		UseRawOutput:           cfg.UseRawOutput,
 		Vars:                   cfg.Vars,
@@ -109,9 +118,27 @@ func (g *NotifyService) Plan(ctx context.Context, param *notifier.ParamExec) err
 		ReplacedResources:      result.ReplacedResources,
 		MovedResources:         result.MovedResources,
 		ImportedResources:      result.ImportedResources,
+		ForgottenResources:     result.ForgottenResources,
+		DeferredResources:      result.DeferredResources,
+		ReadResources:          result.ReadResources,
+		CreatedAddresses:       result.CreatedAddresses,
+		UpdatedAddresses:       result.UpdatedAddresses,
+		DeletedAddresses:       result.DeletedAddresses,
+		ReplacedAddresses:      result.ReplacedAddresses,
+		ImportedAddresses:      result.ImportedAddresses,
+		ResourceChanges:        result.ResourceChanges,
+		OutputChanges:          result.OutputChanges,
+		Diagnostics:            result.Diagnostics,
+		RunURL:                 result.RunURL,
+		PolicyChecks:           result.PolicyChecks,
+		CostEstimation:         result.CostEstimation,
+		Cost:                   result.Cost,
+		Risk:                   result.Risk,
+		ProtectedResources:     result.ProtectedResources,
 		ModuleResults:          result.ModuleResults,
 		AISummary:              aiSummary,
 		SummaryEnabled:         param.AISummarizer != nil,
+		Parser:                 result.Parser,
 	})
 	body, err := template.Execute()
 	if err != nil {
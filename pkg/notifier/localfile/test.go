@@ -0,0 +1,12 @@
+package localfile
+
+import (
+	"context"
+
+	"github.com/mercari/tfnotify/v1/pkg/notifier"
+)
+
+// Test writes the result of terraform test to a file
+func (g *NotifyService) Test(_ context.Context, param *notifier.ParamExec) error {
+	return g.writeResult("test", param, g.client.Config.Parser.Parse(param.CombinedOutput))
+}
@@ -0,0 +1,12 @@
+package localfile
+
+import (
+	"context"
+
+	"github.com/mercari/tfnotify/v1/pkg/notifier"
+)
+
+// Validate writes the result of terraform validate to a file
+func (g *NotifyService) Validate(_ context.Context, param *notifier.ParamExec) error {
+	return g.writeResult("validate", param, g.client.Config.Parser.Parse(param.CombinedOutput))
+}
@@ -2,12 +2,18 @@ package notifier
 
 import (
 	"context"
+
+	"github.com/mercari/tfnotify/v1/pkg/terraform"
 )
 
 // Notifier is a notification interface
 type Notifier interface {
 	Apply(ctx context.Context, param *ParamExec) error
 	Plan(ctx context.Context, param *ParamExec) error
+	Validate(ctx context.Context, param *ParamExec) error
+	Fmt(ctx context.Context, param *ParamExec) error
+	Drift(ctx context.Context, param *ParamExec) error
+	Test(ctx context.Context, param *ParamExec) error
 }
 
 type AISummarizer interface {
@@ -20,5 +26,12 @@ type ParamExec struct {
 	CombinedOutput string
 	CIName         string
 	ExitCode       int
-	AISummarizer   AISummarizer
+	// AISummarizer isn't saved by `--record`
+	AISummarizer AISummarizer `json:"-"`
+	// PlanJSON is the output of `terraform show -json` for the plan file. It is set only by `plan --plan-file`
+	PlanJSON string
+	// PlanFileChecksum is the SHA256 checksum of the plan file. It is set only by `plan --plan-file`
+	PlanFileChecksum string
+	// Cost is the cost estimated by Infracost. It is set only by `plan` when the Infracost file is given
+	Cost *terraform.Cost
 }
@@ -52,6 +52,8 @@ func (s *NotifyService) Apply(ctx context.Context, param *notifier.ParamExec) er
 			"HasError":               result.HasError,
 			"Warning":                result.Warning,
 			"ChangeOutsideTerraform": result.OutsideTerraform,
+			"Diagnostics":            result.Diagnostics,
+			"AppliedResources":       result.AppliedResources,
 			"ErrorMessages":          errMsgs,
 			"ExitCode":               param.ExitCode,
 			"CombinedOutput":         param.CombinedOutput,
@@ -75,6 +77,12 @@ func (s *NotifyService) Apply(ctx context.Context, param *notifier.ParamExec) er
 		Warning:                result.Warning,
 		HasDestroy:             result.HasDestroy,
 		HasError:               result.HasError,
+		ImportCount:            result.ImportCount,
+		AddCount:               result.AddCount,
+		ChangeCount:            result.ChangeCount,
+		DestroyCount:           result.DestroyCount,
+		MoveCount:              result.MoveCount,
+		ForgetCount:            result.ForgetCount,
 		Link:                   cfg.CI.Link,
 		UseRawOutput:           cfg.UseRawOutput,
 		Vars:                   cfg.Vars,
@@ -88,9 +96,15 @@ func (s *NotifyService) Apply(ctx context.Context, param *notifier.ParamExec) er
 		UpdatedResources:       result.UpdatedResources,
 		DeletedResources:       result.DeletedResources,
 		ReplacedResources:      result.ReplacedResources,
+		Diagnostics:            result.Diagnostics,
+		RunURL:                 result.RunURL,
+		PolicyChecks:           result.PolicyChecks,
+		CostEstimation:         result.CostEstimation,
+		AppliedResources:       result.AppliedResources,
 		ModuleResults:          result.ModuleResults,
 		AISummary:              aiSummary,
 		SummaryEnabled:         param.AISummarizer != nil,
+		Parser:                 result.Parser,
 	})
 
 	body, err := template.Execute()
@@ -0,0 +1,19 @@
+package slack
+
+import (
+	"context"
+
+	"github.com/mercari/tfnotify/v1/pkg/notifier"
+)
+
+// Drift posts Slack message for drift detection results.
+// Like plan, only drift and failures are notified when notify_on_plan_error is enabled.
+func (s *NotifyService) Drift(ctx context.Context, param *notifier.ParamExec) error {
+	result := s.client.Config.Parser.Parse(param.CombinedOutput)
+	notify := param.ExitCode != 0 || result.HasError || !result.HasNoChanges
+	status := "⚠️ Terraform drift detected. See thread for details."
+	if result.HasError || result.HasParseError {
+		status = "❌ Terraform drift detection failed. See thread for details."
+	}
+	return s.notifyResult(ctx, "drift", param, result, notify, status)
+}
@@ -0,0 +1,16 @@
+package slack
+
+import (
+	"context"
+
+	"github.com/mercari/tfnotify/v1/pkg/notifier"
+	"github.com/mercari/tfnotify/v1/pkg/terraform"
+)
+
+// Fmt posts Slack message for terraform fmt -check results.
+// Like plan, only unformatted files and failures are notified when notify_on_plan_error is enabled.
+func (s *NotifyService) Fmt(ctx context.Context, param *notifier.ParamExec) error {
+	result := terraform.ParseFmt(s.client.Config.Parser, param.CombinedOutput, param.ExitCode)
+	notify := param.ExitCode != 0 || result.HasError || len(result.UnformattedFiles) != 0
+	return s.notifyResult(ctx, "fmt", param, result, notify, "❌ Terraform fmt check failed. See thread for details.")
+}
@@ -0,0 +1,90 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mercari/tfnotify/v1/pkg/notifier"
+	"github.com/mercari/tfnotify/v1/pkg/terraform"
+	"github.com/sirupsen/logrus"
+)
+
+// notifyResult posts the result of validate, fmt, test, or drift if notify is true.
+// Like plan, they are notified only when notify_on_plan_error is enabled.
+// status is the parent message of a thread.
+func (s *NotifyService) notifyResult(ctx context.Context, command string, param *notifier.ParamExec, result terraform.ParseResult, notify bool, status string) error {
+	cfg := s.client.Config
+	template := cfg.Template
+	if result.HasParseError {
+		template = cfg.ParseErrorTemplate
+	} else if result.Error != nil {
+		return result.Error
+	}
+
+	logE := logrus.WithField("command", command)
+	if !cfg.NotifyOnPlanError || !notify {
+		logE.WithFields(logrus.Fields{
+			"exit_code":            param.ExitCode,
+			"notify_on_plan_error": cfg.NotifyOnPlanError,
+		}).Debug("Skipping Slack notification (nothing to notify or notification disabled)")
+		return nil
+	}
+
+	template.SetValue(terraform.CommonTemplate{
+		Result:                 result.Result,
+		ChangeOutsideTerraform: result.OutsideTerraform,
+		Warning:                result.Warning,
+		HasError:               result.HasError,
+		Link:                   cfg.CI.Link,
+		UseRawOutput:           cfg.UseRawOutput,
+		Vars:                   cfg.Vars,
+		Templates:              cfg.Templates,
+		Stdout:                 param.Stdout,
+		Stderr:                 param.Stderr,
+		CombinedOutput:         param.CombinedOutput,
+		ExitCode:               param.ExitCode,
+		ErrorMessages:          []string{},
+		Diagnostics:            result.Diagnostics,
+		UnformattedFiles:       result.UnformattedFiles,
+		DriftedResources:       result.DriftedResources,
+		TestFiles:              result.TestFiles,
+	})
+	body, err := template.Execute()
+	if err != nil {
+		return err
+	}
+
+	title := cfg.PlanTitle
+	if title == "" {
+		title = cfg.Title // Fallback to default title
+	}
+	message := cfg.PlanMessage
+	if message == "" {
+		message = cfg.Message // Fallback to default message
+	}
+
+	logE.Info("Posting the result to Slack channel")
+
+	if cfg.UseThreads {
+		parentMessage := buildParentMessage(title, message, status)
+
+		timestamp, err := s.postMessageAndGetTimestamp(ctx, parentMessage, nil)
+		if err != nil {
+			return err
+		}
+
+		threadMessage := buildThreadMessage(param.CombinedOutput)
+		logE.WithField("parent_ts", timestamp).Info("Sending error details in thread")
+		return s.postMessage(ctx, threadMessage, &timestamp)
+	}
+
+	fullMessage := ""
+	if title != "" {
+		fullMessage = fmt.Sprintf("*%s*\n\n", title)
+	}
+	if message != "" {
+		fullMessage += fmt.Sprintf("%s\n\n", message)
+	}
+	_, err = s.postMessageAndGetTimestamp(ctx, fullMessage+body, nil)
+	return err
+}
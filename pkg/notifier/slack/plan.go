@@ -19,6 +19,7 @@ func (s *NotifyService) Plan(ctx context.Context, param *notifier.ParamExec) err
 	template := cfg.Template
 
 	result := parser.Parse(param.CombinedOutput)
+	result.Cost = param.Cost
 	if result.HasParseError {
 		template = cfg.ParseErrorTemplate
 	} else {
@@ -51,6 +52,8 @@ func (s *NotifyService) Plan(ctx context.Context, param *notifier.ParamExec) err
 			"HasError":               result.HasError,
 			"Warning":                result.Warning,
 			"ChangeOutsideTerraform": result.OutsideTerraform,
+			"Diagnostics":            result.Diagnostics,
+			"OutputChanges":          result.OutputChanges,
 			"ExitCode":               param.ExitCode,
 			"CombinedOutput":         param.CombinedOutput,
 			"OperationType":          operationType,
@@ -73,6 +76,12 @@ func (s *NotifyService) Plan(ctx context.Context, param *notifier.ParamExec) err
 		Warning:                result.Warning,
 		HasDestroy:             result.HasDestroy,
 		HasError:               result.HasError,
+		ImportCount:            result.ImportCount,
+		AddCount:               result.AddCount,
+		ChangeCount:            result.ChangeCount,
+		DestroyCount:           result.DestroyCount,
+		MoveCount:              result.MoveCount,
+		ForgetCount:            result.ForgetCount,
 		Link:                   cfg.CI.Link,
 		UseRawOutput:           cfg.UseRawOutput,
 		Vars:                   cfg.Vars,
@@ -86,9 +95,29 @@ func (s *NotifyService) Plan(ctx context.Context, param *notifier.ParamExec) err
 		UpdatedResources:       result.UpdatedResources,
 		DeletedResources:       result.DeletedResources,
 		ReplacedResources:      result.ReplacedResources,
+		MovedResources:         result.MovedResources,
+		ImportedResources:      result.ImportedResources,
+		ForgottenResources:     result.ForgottenResources,
+		DeferredResources:      result.DeferredResources,
+		ReadResources:          result.ReadResources,
+		CreatedAddresses:       result.CreatedAddresses,
+		UpdatedAddresses:       result.UpdatedAddresses,
+		DeletedAddresses:       result.DeletedAddresses,
+		ReplacedAddresses:      result.ReplacedAddresses,
+		ImportedAddresses:      result.ImportedAddresses,
+		ResourceChanges:        result.ResourceChanges,
+		OutputChanges:          result.OutputChanges,
+		Diagnostics:            result.Diagnostics,
+		RunURL:                 result.RunURL,
+		PolicyChecks:           result.PolicyChecks,
+		CostEstimation:         result.CostEstimation,
+		Cost:                   result.Cost,
+		Risk:                   result.Risk,
+		ProtectedResources:     result.ProtectedResources,
 		ModuleResults:          result.ModuleResults,
 		AISummary:              aiSummary,
 		SummaryEnabled:         param.AISummarizer != nil,
+		Parser:                 result.Parser,
 	})
 
 	body, err := template.Execute()
@@ -127,6 +156,10 @@ func (s *NotifyService) Plan(ctx context.Context, param *notifier.ParamExec) err
 		shouldNotify = true
 		logrus.WithField("exit_code", param.ExitCode).Info("Plan failed, notifying Slack (notify_on_plan_error enabled)")
 	}
+	if cfg.NotifyOnRiskLevel != "" && result.Risk != nil && terraform.CompareRiskLevel(result.Risk.Level, cfg.NotifyOnRiskLevel) >= 0 {
+		shouldNotify = true
+		logrus.WithField("risk_level", result.Risk.Level).Info("Plan is risky, notifying Slack (notify_on_risk_level enabled)")
+	}
 
 	if !shouldNotify {
 		logrus.WithFields(logrus.Fields{
@@ -137,6 +170,16 @@ func (s *NotifyService) Plan(ctx context.Context, param *notifier.ParamExec) err
 		return nil
 	}
 
+	if result.Risk != nil {
+		if channelID := cfg.RiskChannelIDs[result.Risk.Level]; channelID != "" {
+			logrus.WithFields(logrus.Fields{
+				"risk_level": result.Risk.Level,
+				"channel_id": channelID,
+			}).Info("Routing the plan notification to the channel of the risk level")
+			s = s.withChannel(channelID)
+		}
+	}
+
 	// If using threads, send parent message then error details in thread
 	if cfg.UseThreads && param.ExitCode != 0 {
 		// Send parent summary message
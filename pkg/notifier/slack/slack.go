@@ -87,6 +87,10 @@ type Config struct {
 	NotifyOnPlanError  bool
 	NotifyOnApplyError bool
 	UseThreads         bool
+	// NotifyOnRiskLevel sends plan notifications whose risk level is equal to or higher than it
+	NotifyOnRiskLevel string
+	// RiskChannelIDs is the channels per risk level for plan notifications
+	RiskChannelIDs map[string]string
 }
 
 // NewClient creates a new Slack client
@@ -125,3 +129,15 @@ func (c *Client) Notify() *NotifyService {
 		client: c,
 	}
 }
+
+// withChannel returns NotifyService which posts messages to channelID instead of the configured channel
+func (s *NotifyService) withChannel(channelID string) *NotifyService {
+	cfg := *s.client.Config
+	cfg.ChannelID = channelID
+	return &NotifyService{
+		client: &Client{
+			Client: s.client.Client,
+			Config: &cfg,
+		},
+	}
+}
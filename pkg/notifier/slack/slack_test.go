@@ -185,3 +185,63 @@ func TestTruncateForSlack(t *testing.T) {
 		t.Error("truncateForSlack() missing truncation note")
 	}
 }
+
+// TestCommandsFollowNotifyOnPlanError ensures validate, fmt, test, and drift
+// are posted only when notify_on_plan_error is enabled and there is something to notify.
+func TestCommandsFollowNotifyOnPlanError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		notifyOnPlanError bool
+		parser            terraform.Parser
+		output            string
+		exitCode          int
+		notify            func(s *NotifyService, ctx context.Context, param *notifier.ParamExec) error
+	}{
+		{
+			name:     "validate disabled",
+			parser:   terraform.NewValidateParser(),
+			output:   failedPlanOutput,
+			exitCode: 1,
+			notify:   (*NotifyService).Validate,
+		},
+		{
+			name:              "fmt formatted",
+			notifyOnPlanError: true,
+			parser:            terraform.NewFmtParser(),
+			notify:            (*NotifyService).Fmt,
+		},
+		{
+			name:     "test disabled",
+			parser:   terraform.NewTestParser(),
+			output:   failedPlanOutput,
+			exitCode: 1,
+			notify:   (*NotifyService).Test,
+		},
+		{
+			name:              "no drift",
+			notifyOnPlanError: true,
+			parser:            terraform.NewDriftParser(),
+			output:            "No changes. Your infrastructure still matches the configuration.",
+			notify:            (*NotifyService).Drift,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := newTestClient(t, &Config{
+				NotifyOnPlanError: tt.notifyOnPlanError,
+				UseThreads:        true,
+				Parser:            tt.parser,
+			})
+			err := tt.notify(client.Notify(), context.Background(), &notifier.ParamExec{
+				CombinedOutput: tt.output,
+				ExitCode:       tt.exitCode,
+			})
+			if err != nil {
+				t.Errorf("posted to Slack: %v", err)
+			}
+		})
+	}
+}
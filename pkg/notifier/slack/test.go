@@ -0,0 +1,15 @@
+package slack
+
+import (
+	"context"
+
+	"github.com/mercari/tfnotify/v1/pkg/notifier"
+)
+
+// Test posts Slack message for terraform test results.
+// Like plan, only failures are notified when notify_on_plan_error is enabled.
+func (s *NotifyService) Test(ctx context.Context, param *notifier.ParamExec) error {
+	result := s.client.Config.Parser.Parse(param.CombinedOutput)
+	notify := param.ExitCode != 0 || result.HasError
+	return s.notifyResult(ctx, "test", param, result, notify, "❌ Terraform test failed. See thread for details.")
+}
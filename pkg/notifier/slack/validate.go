@@ -0,0 +1,15 @@
+package slack
+
+import (
+	"context"
+
+	"github.com/mercari/tfnotify/v1/pkg/notifier"
+)
+
+// Validate posts Slack message for terraform validate results.
+// Like plan, only failures are notified when notify_on_plan_error is enabled.
+func (s *NotifyService) Validate(ctx context.Context, param *notifier.ParamExec) error {
+	result := s.client.Config.Parser.Parse(param.CombinedOutput)
+	notify := param.ExitCode != 0 || result.HasError
+	return s.notifyResult(ctx, "validate", param, result, notify, "❌ Terraform validate failed. See thread for details.")
+}
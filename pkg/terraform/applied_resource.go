@@ -0,0 +1,115 @@
+package terraform
+
+import (
+	"strings"
+	"time"
+)
+
+// Statuses of AppliedResource
+const (
+	AppliedResourceStatusSucceeded = "succeeded"
+	AppliedResourceStatusFailed    = "failed"
+)
+
+// AppliedResource is the outcome of a resource in terraform apply.
+// It is parsed from progress lines such as `aws_instance.web: Creation complete after 3s [id=i-1234]`.
+type AppliedResource struct {
+	Address string
+	// Action is one of "create", "update", "delete", "replace", and "import"
+	Action string
+	// Status is empty if the resource neither completed nor failed, e.g. the output is truncated
+	Status string
+	// ID is the ID of the resource printed by Terraform. It is empty if Terraform doesn't print it.
+	ID string
+	// Duration is the time taken to apply the resource.
+	// If the resource failed, it is the last elapsed time Terraform reported.
+	Duration time.Duration
+}
+
+// applyAction converts the verb of a progress line to the action
+func applyAction(verb string) string {
+	switch verb {
+	case "Creating", "Creation":
+		return "create"
+	case "Modifying", "Modifications":
+		return "update"
+	case "Destroying", "Destruction":
+		return "delete"
+	case "Importing", "Import":
+		return "import"
+	}
+	return ""
+}
+
+// parseAppliedResources parses progress lines of terraform apply.
+// Resources which started but didn't complete are marked as failed if the apply failed.
+// Destroying and creating the same resource is treated as a replacement.
+func (p *ApplyParser) parseAppliedResources(lines []string, hasError bool, diags []*Diagnostic) []*AppliedResource { //nolint:cyclop
+	var resources []*AppliedResource
+	byAddress := map[string]*AppliedResource{}
+	// elapsed time before the current phase, e.g. destroying of a replacement
+	base := map[string]time.Duration{}
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if m := p.Start.FindStringSubmatch(line); m != nil {
+			action := applyAction(m[2])
+			res, ok := byAddress[m[1]]
+			if !ok {
+				res = &AppliedResource{
+					Address: m[1],
+					Action:  action,
+				}
+				byAddress[m[1]] = res
+				resources = append(resources, res)
+			} else if res.Action != action && (action == "create" || action == "delete") {
+				res.Action = "replace"
+			}
+			// Keep the ID of the new object when the deposed object is destroyed
+			if m[3] != "" && res.ID == "" {
+				res.ID = m[3]
+			}
+			base[m[1]] = res.Duration
+			res.Status = ""
+			continue
+		}
+		if m := p.Still.FindStringSubmatch(line); m != nil {
+			if res, ok := byAddress[m[1]]; ok {
+				res.Duration = base[m[1]] + parseApplyDuration(m[2])
+			}
+			continue
+		}
+		if m := p.Complete.FindStringSubmatch(line); m != nil {
+			res, ok := byAddress[m[1]]
+			if !ok {
+				continue
+			}
+			// The duration of a replacement is the sum of destroying and creating
+			res.Duration = base[m[1]] + parseApplyDuration(m[3])
+			if m[4] != "" {
+				res.ID = m[4]
+			}
+			res.Status = AppliedResourceStatusSucceeded
+		}
+	}
+
+	failed := map[string]bool{}
+	for _, diag := range diags {
+		if diag.Severity == DiagnosticSeverityError && diag.Address != "" {
+			failed[diag.Address] = true
+		}
+	}
+	for _, res := range resources {
+		if failed[res.Address] || (hasError && res.Status != AppliedResourceStatusSucceeded) {
+			res.Status = AppliedResourceStatusFailed
+		}
+	}
+	return resources
+}
+
+func parseApplyDuration(s string) time.Duration {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0
+	}
+	return d
+}
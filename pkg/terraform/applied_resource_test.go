@@ -0,0 +1,115 @@
+package terraform
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const applyProgressResult = `
+null_resource.update: Modifying... [id=1111]
+aws_instance.replace: Destroying... [id=i-0001]
+null_resource.create: Creating...
+null_resource.create: Creation complete after 1s [id=2222]
+null_resource.update: Modifications complete after 0s [id=1111]
+aws_instance.replace: Still destroying... [id=i-0001, 10s elapsed]
+aws_instance.replace: Destruction complete after 12s
+aws_instance.replace: Creating...
+aws_instance.replace: Creation complete after 1m3s [id=i-0002]
+module.db.aws_db_instance.main: Modifying... [id=db-1]
+null_resource.delete: Destroying... [id=3333]
+null_resource.delete: Destruction complete after 0s
+module.db.aws_db_instance.main: Still modifying... [id=db-1, 10m0s elapsed]
+╷
+│ Error: updating RDS DB Instance (db-1): operation error RDS: ModifyDBInstance, api error InvalidParameterCombination
+│
+│   with module.db.aws_db_instance.main,
+│   on db/main.tf line 1, in resource "aws_db_instance" "main":
+│    1: resource "aws_db_instance" "main" {
+│
+╵
+`
+
+func TestApplyParser_AppliedResources(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name      string
+		body      string
+		resources []*AppliedResource
+	}{
+		{
+			name: "failure",
+			body: applyProgressResult,
+			resources: []*AppliedResource{
+				{
+					Address:  "null_resource.update",
+					Action:   "update",
+					Status:   AppliedResourceStatusSucceeded,
+					ID:       "1111",
+					Duration: 0,
+				},
+				{
+					Address:  "aws_instance.replace",
+					Action:   "replace",
+					Status:   AppliedResourceStatusSucceeded,
+					ID:       "i-0002",
+					Duration: time.Minute + 15*time.Second,
+				},
+				{
+					Address:  "null_resource.create",
+					Action:   "create",
+					Status:   AppliedResourceStatusSucceeded,
+					ID:       "2222",
+					Duration: time.Second,
+				},
+				{
+					Address:  "module.db.aws_db_instance.main",
+					Action:   "update",
+					Status:   AppliedResourceStatusFailed,
+					ID:       "db-1",
+					Duration: 10 * time.Minute,
+				},
+				{
+					Address:  "null_resource.delete",
+					Action:   "delete",
+					Status:   AppliedResourceStatusSucceeded,
+					ID:       "3333",
+					Duration: 0,
+				},
+			},
+		},
+		{
+			name: "create before destroy",
+			body: `aws_instance.web: Creating...
+aws_instance.web: Creation complete after 30s [id=i-0002]
+aws_instance.web (deposed object 1a2b3c4d): Destroying... [id=i-0001]
+aws_instance.web: Destruction complete after 5s
+
+Apply complete! Resources: 1 added, 0 changed, 1 destroyed.
+`,
+			resources: []*AppliedResource{
+				{
+					Address:  "aws_instance.web",
+					Action:   "replace",
+					Status:   AppliedResourceStatusSucceeded,
+					ID:       "i-0002",
+					Duration: 35 * time.Second,
+				},
+			},
+		},
+		{
+			name: "no progress",
+			body: applySuccessResult,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			result := NewApplyParser().Parse(testCase.body)
+			if diff := cmp.Diff(testCase.resources, result.AppliedResources); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package terraform
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+
+	tmpl "github.com/mercari/tfnotify/v1/pkg/template"
+)
+
+// conditionData is the data of conditions of labels.
+// The fields of ParseResult such as `.DestroyCount` and `.Vars` are available.
+type conditionData struct {
+	ParseResult
+
+	Vars map[string]string
+}
+
+// HasResourceType returns true if any changed resource matches any of glob patterns like `aws_iam_*`
+func (d *conditionData) HasResourceType(patterns ...string) bool {
+	for _, target := range riskTargets(d.ParseResult) {
+		if matchGlobs(patterns, target.address.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasModule returns true if any resource in a module matching any of glob patterns is changed.
+// Patterns match module paths like `module.vpc` and Terragrunt modules like `prod/vpc`.
+func (d *conditionData) HasModule(patterns ...string) bool {
+	for _, target := range riskTargets(d.ParseResult) {
+		if matchGlobs(patterns, target.address.ModulePath) || (target.module != "" && matchGlobs(patterns, target.module)) {
+			return true
+		}
+	}
+	return false
+}
+
+// EvaluateCondition evaluates the condition of a label against result.
+// The condition is either a template like `{{gt .DestroyCount 0}}` or an expression without braces like `gt .DestroyCount 0`.
+// It is satisfied if the rendered string is true. An empty condition is always satisfied.
+func EvaluateCondition(condition string, result ParseResult, vars map[string]string) (bool, error) {
+	if condition == "" {
+		return true, nil
+	}
+	if !strings.Contains(condition, "{{") {
+		condition = "{{" + condition + "}}"
+	}
+	tpl, err := texttemplate.New("condition").Funcs(tmpl.TxtFuncMap()).Parse(condition)
+	if err != nil {
+		return false, fmt.Errorf("parse a condition: %w", err)
+	}
+	buf := &bytes.Buffer{}
+	if err := tpl.Execute(buf, &conditionData{
+		ParseResult: result,
+		Vars:        vars,
+	}); err != nil {
+		return false, fmt.Errorf("evaluate a condition: %w", err)
+	}
+	s := strings.TrimSpace(buf.String())
+	if s == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, fmt.Errorf("the result of a condition must be a boolean: %q", s)
+	}
+	return b, nil
+}
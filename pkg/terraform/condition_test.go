@@ -0,0 +1,94 @@
+package terraform
+
+import (
+	"testing"
+)
+
+func TestEvaluateCondition(t *testing.T) {
+	t.Parallel()
+	result := ParseResult{
+		DestroyCount: 1,
+		ModuleResults: []*ModuleResult{
+			{
+				Module:           "prod/iam",
+				CreatedAddresses: parseResourceAddresses([]string{"aws_iam_role.foo"}),
+			},
+			{
+				Module:           "dev/network",
+				DeletedAddresses: parseResourceAddresses([]string{"module.vpc.aws_subnet.a"}),
+			},
+		},
+	}
+	testCases := []struct {
+		name      string
+		condition string
+		exp       bool
+		isErr     bool
+	}{
+		{
+			name: "empty condition",
+			exp:  true,
+		},
+		{
+			name:      "expression",
+			condition: "gt .DestroyCount 0",
+			exp:       true,
+		},
+		{
+			name:      "template",
+			condition: "{{ eq .AddCount 0 }}",
+			exp:       true,
+		},
+		{
+			name:      "resource type",
+			condition: `.HasResourceType "aws_iam_*" "google_*_iam_*"`,
+			exp:       true,
+		},
+		{
+			name:      "resource type doesn't match",
+			condition: `.HasResourceType "aws_kms_*"`,
+		},
+		{
+			name:      "terragrunt module",
+			condition: `.HasModule "prod/*"`,
+			exp:       true,
+		},
+		{
+			name:      "module path",
+			condition: `and (.HasModule "module.vpc") (eq .Vars.env "dev")`,
+			exp:       true,
+		},
+		{
+			name:      "empty result",
+			condition: `{{if .HasDestroy}}true{{end}}`,
+		},
+		{
+			name:      "not boolean",
+			condition: "len .ModuleResults",
+			isErr:     true,
+		},
+		{
+			name:      "invalid template",
+			condition: "gt .DestroyCount",
+			isErr:     true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			b, err := EvaluateCondition(testCase.condition, result, map[string]string{"env": "dev"})
+			if err != nil {
+				if !testCase.isErr {
+					t.Fatal(err)
+				}
+				return
+			}
+			if testCase.isErr {
+				t.Fatal("error must be returned")
+			}
+			if b != testCase.exp {
+				t.Errorf("wanted %v, got %v", testCase.exp, b)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package terraform
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Severities of Diagnostic
+const (
+	DiagnosticSeverityError   = "error"
+	DiagnosticSeverityWarning = "warning"
+)
+
+// Diagnostic is an error or warning reported by Terraform.
+// File and Line are empty if the diagnostic isn't related to the configuration.
+type Diagnostic struct {
+	Severity string
+	Summary  string
+	Detail   string
+	File     string
+	Line     int
+	// Address is the address of the resource related to the diagnostic, e.g. `aws_s3_bucket.foo`
+	Address string
+	// Snippet is the source code printed under the location, e.g. `  12:   acl = "private"`
+	Snippet string
+}
+
+var (
+	diagnosticStartRe    = regexp.MustCompile(`^(Error|Warning): (.*)$`)
+	diagnosticLocationRe = regexp.MustCompile(`^\s+on (.+?) line (\d+)(?:, in .*)?:$`)
+	diagnosticWithRe     = regexp.MustCompile(`^\s+with (.+),$`)
+)
+
+// parseDiagnostics parses diagnostics like the following.
+// Diagnostics of Terraform v0.15+ are surrounded by a box, and older ones aren't.
+//
+//	╷
+//	│ Warning: Argument is deprecated
+//	│
+//	│   with aws_s3_bucket.foo,
+//	│   on main.tf line 12, in resource "aws_s3_bucket" "foo":
+//	│   12:   acl = "private"
+//	│
+//	│ Use the aws_s3_bucket_acl resource instead
+//	╵
+func parseDiagnostics(lines []string) []*Diagnostic {
+	var diags []*Diagnostic
+	var body []string
+	var current *Diagnostic
+	flush := func() {
+		if current == nil {
+			return
+		}
+		current.setBody(body)
+		diags = append(diags, current)
+		current = nil
+		body = nil
+	}
+	for _, line := range lines {
+		// The end of a box, the start of a box, and rules end a diagnostic
+		if strings.HasPrefix(line, "╵") || strings.HasPrefix(line, "╷") ||
+			strings.HasPrefix(line, "─────") || strings.HasPrefix(line, "-----") {
+			flush()
+			continue
+		}
+		content := trimDiagnosticBar(line)
+		if m := diagnosticStartRe.FindStringSubmatch(content); m != nil {
+			flush()
+			current = &Diagnostic{
+				Severity: strings.ToLower(m[1]),
+				Summary:  strings.TrimSpace(m[2]),
+			}
+			continue
+		}
+		if current != nil {
+			body = append(body, content)
+		}
+	}
+	flush()
+	return diags
+}
+
+func trimDiagnosticBar(line string) string {
+	for _, bar := range []string{"│", "|"} {
+		if s, ok := strings.CutPrefix(line, bar); ok {
+			return strings.TrimPrefix(s, " ")
+		}
+	}
+	return line
+}
+
+// setBody sets the location, the snippet, and the detail from lines following the summary.
+func (d *Diagnostic) setBody(lines []string) {
+	var snippet, detail []string
+	inSnippet := false
+	for _, line := range lines {
+		if inSnippet {
+			if strings.TrimSpace(line) == "" {
+				inSnippet = false
+				continue
+			}
+			snippet = append(snippet, line)
+			continue
+		}
+		if m := diagnosticWithRe.FindStringSubmatch(line); m != nil && d.File == "" && len(detail) == 0 {
+			d.Address = m[1]
+			continue
+		}
+		if m := diagnosticLocationRe.FindStringSubmatch(line); m != nil && d.File == "" && len(detail) == 0 {
+			d.File = m[1]
+			d.Line, _ = strconv.Atoi(m[2])
+			inSnippet = true
+			continue
+		}
+		if len(detail) == 0 && strings.TrimSpace(line) == "" {
+			continue
+		}
+		detail = append(detail, line)
+	}
+	d.Snippet = strings.Join(snippet, "\n")
+	d.Detail = strings.TrimSpace(strings.Join(detail, "\n"))
+}
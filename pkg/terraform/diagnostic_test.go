@@ -0,0 +1,81 @@
+package terraform
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const planWarningsResult = `
+null_resource.foo: Refreshing state... [id=6068603774747257119]
+
+No changes. Your infrastructure matches the configuration.
+
+Terraform has compared your real infrastructure against your configuration
+and found no differences, so no changes are needed.
+╷
+│ Warning: Argument is deprecated
+│ 
+│   with aws_s3_bucket.foo,
+│   on main.tf line 12, in resource "aws_s3_bucket" "foo":
+│   12:   acl = "private"
+│ 
+│ Use the aws_s3_bucket_acl resource instead
+│ 
+│ (and 3 more similar warnings elsewhere)
+╵
+╷
+│ Warning: Version constraints inside provider configuration blocks are deprecated
+│ 
+│   on providers.tf line 2, in provider "aws":
+│    2:   version = "~> 5.0"
+│ 
+│ Terraform 0.13 and earlier allowed provider version constraints inside the
+│ provider configuration block, but that is now deprecated.
+╵
+╷
+│ Error: Invalid value for variable
+│ 
+│   on variables.tf line 1:
+│    1: variable "env" {
+│     ├────────────────
+│     │ var.env is "foo"
+│ 
+│ The environment must be dev or prd.
+╵
+`
+
+func TestParseDiagnostics(t *testing.T) {
+	t.Parallel()
+	exp := []*Diagnostic{
+		{
+			Severity: "warning",
+			Summary:  "Argument is deprecated",
+			Detail:   "Use the aws_s3_bucket_acl resource instead\n\n(and 3 more similar warnings elsewhere)",
+			File:     "main.tf",
+			Line:     12,
+			Address:  "aws_s3_bucket.foo",
+			Snippet:  `  12:   acl = "private"`,
+		},
+		{
+			Severity: "warning",
+			Summary:  "Version constraints inside provider configuration blocks are deprecated",
+			Detail:   "Terraform 0.13 and earlier allowed provider version constraints inside the\nprovider configuration block, but that is now deprecated.",
+			File:     "providers.tf",
+			Line:     2,
+			Snippet:  `   2:   version = "~> 5.0"`,
+		},
+		{
+			Severity: "error",
+			Summary:  "Invalid value for variable",
+			Detail:   "The environment must be dev or prd.",
+			File:     "variables.tf",
+			Line:     1,
+			Snippet:  "   1: variable \"env\" {\n    ├────────────────\n    │ var.env is \"foo\"",
+		},
+	}
+	if diff := cmp.Diff(exp, parseDiagnostics(strings.Split(planWarningsResult, "\n"))); diff != "" {
+		t.Error(diff)
+	}
+}
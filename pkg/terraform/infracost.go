@@ -0,0 +1,158 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+)
+
+// Cost is the monthly cost estimated by Infracost.
+// Before is the cost of the base branch, After is the cost with the changes, and Delta is After - Before.
+type Cost struct {
+	Currency string
+	Before   float64
+	After    float64
+	Delta    float64
+	// Modules is the costs per project of Infracost. A project is a Terragrunt module in a Terragrunt repository
+	Modules []*ModuleCost
+	// Resources is the costs of the resources whose cost is changed
+	Resources []*ResourceCost
+}
+
+// ModuleCost is the monthly cost of a project of Infracost
+type ModuleCost struct {
+	Path   string
+	Before float64
+	After  float64
+	Delta  float64
+}
+
+// ResourceCost is the monthly cost of a resource
+type ResourceCost struct {
+	Address string
+	// Module is the path of the project which has the resource
+	Module string
+	Before float64
+	After  float64
+	Delta  float64
+}
+
+// infracostOutput is the JSON output of `infracost breakdown` and `infracost diff`
+type infracostOutput struct {
+	Currency             string              `json:"currency"`
+	Projects             []*infracostProject `json:"projects"`
+	TotalMonthlyCost     *string             `json:"totalMonthlyCost"`
+	PastTotalMonthlyCost *string             `json:"pastTotalMonthlyCost"`
+	DiffTotalMonthlyCost *string             `json:"diffTotalMonthlyCost"`
+}
+
+type infracostProject struct {
+	Name     string `json:"name"`
+	Metadata struct {
+		Path string `json:"path"`
+	} `json:"metadata"`
+	PastBreakdown *infracostBreakdown `json:"pastBreakdown"`
+	Breakdown     *infracostBreakdown `json:"breakdown"`
+}
+
+type infracostBreakdown struct {
+	Resources        []*infracostResource `json:"resources"`
+	TotalMonthlyCost *string              `json:"totalMonthlyCost"`
+}
+
+type infracostResource struct {
+	Name        string  `json:"name"`
+	MonthlyCost *string `json:"monthlyCost"`
+}
+
+// ReadInfracostFile reads the JSON output of `infracost breakdown --format json` or `infracost diff --format json`.
+// The costs of `infracost breakdown` without `--compare-to` are compared with zero.
+func ReadInfracostFile(p string) (*Cost, error) {
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return nil, fmt.Errorf("read an Infracost file: %w", err)
+	}
+	return ParseInfracost(b)
+}
+
+// ParseInfracost parses the JSON output of Infracost
+func ParseInfracost(b []byte) (*Cost, error) {
+	out := &infracostOutput{}
+	if err := json.Unmarshal(b, out); err != nil {
+		return nil, fmt.Errorf("parse an Infracost file as JSON: %w", err)
+	}
+	cost := &Cost{
+		Currency: out.Currency,
+		Before:   parseInfracostCost(out.PastTotalMonthlyCost),
+		After:    parseInfracostCost(out.TotalMonthlyCost),
+	}
+	cost.Delta = cost.After - cost.Before
+	if out.DiffTotalMonthlyCost != nil {
+		cost.Delta = parseInfracostCost(out.DiffTotalMonthlyCost)
+	}
+	for _, project := range out.Projects {
+		path := project.Metadata.Path
+		if path == "" {
+			path = project.Name
+		}
+		module := &ModuleCost{Path: path}
+		resources := map[string]*ResourceCost{}
+		var addresses []string
+		for _, breakdown := range []*infracostBreakdown{project.PastBreakdown, project.Breakdown} {
+			if breakdown == nil {
+				continue
+			}
+			for _, rsc := range breakdown.Resources {
+				if _, ok := resources[rsc.Name]; !ok {
+					resources[rsc.Name] = &ResourceCost{Address: rsc.Name, Module: path}
+					addresses = append(addresses, rsc.Name)
+				}
+				if breakdown == project.PastBreakdown {
+					resources[rsc.Name].Before = parseInfracostCost(rsc.MonthlyCost)
+				} else {
+					resources[rsc.Name].After = parseInfracostCost(rsc.MonthlyCost)
+				}
+			}
+		}
+		if project.PastBreakdown != nil {
+			module.Before = parseInfracostCost(project.PastBreakdown.TotalMonthlyCost)
+		}
+		if project.Breakdown != nil {
+			module.After = parseInfracostCost(project.Breakdown.TotalMonthlyCost)
+		}
+		module.Delta = module.After - module.Before
+		cost.Modules = append(cost.Modules, module)
+		for _, address := range addresses {
+			rsc := resources[address]
+			rsc.Delta = rsc.After - rsc.Before
+			if rsc.Delta != 0 {
+				cost.Resources = append(cost.Resources, rsc)
+			}
+		}
+	}
+	// The resources with the largest increase come first
+	slices.SortStableFunc(cost.Resources, func(a, b *ResourceCost) int {
+		switch {
+		case a.Delta > b.Delta:
+			return -1
+		case a.Delta < b.Delta:
+			return 1
+		}
+		return 0
+	})
+	return cost, nil
+}
+
+// parseInfracostCost parses a cost of Infracost, which is a decimal string or null
+func parseInfracostCost(s *string) float64 {
+	if s == nil {
+		return 0
+	}
+	f, err := strconv.ParseFloat(*s, 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
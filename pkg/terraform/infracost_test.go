@@ -0,0 +1,82 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+const infracostDiffResult = `{
+  "version": "0.2",
+  "currency": "USD",
+  "projects": [
+    {
+      "name": "mercari/infra/modules/vpc",
+      "metadata": {"path": "modules/vpc"},
+      "pastBreakdown": {
+        "resources": [
+          {"name": "aws_nat_gateway.main", "monthlyCost": "32.85"}
+        ],
+        "totalMonthlyCost": "32.85"
+      },
+      "breakdown": {
+        "resources": [
+          {"name": "aws_nat_gateway.main", "monthlyCost": "32.85"},
+          {"name": "aws_nat_gateway.sub", "monthlyCost": "32.85"}
+        ],
+        "totalMonthlyCost": "65.7"
+      }
+    },
+    {
+      "name": "mercari/infra/modules/db",
+      "metadata": {"path": "modules/db"},
+      "pastBreakdown": {
+        "resources": [
+          {"name": "aws_db_instance.main", "monthlyCost": "100"},
+          {"name": "aws_s3_bucket.logs", "monthlyCost": null}
+        ],
+        "totalMonthlyCost": "100"
+      },
+      "breakdown": {
+        "resources": [
+          {"name": "aws_db_instance.main", "monthlyCost": "50"},
+          {"name": "aws_s3_bucket.logs", "monthlyCost": null}
+        ],
+        "totalMonthlyCost": "50"
+      }
+    }
+  ],
+  "totalMonthlyCost": "115.7",
+  "pastTotalMonthlyCost": "132.85",
+  "diffTotalMonthlyCost": "-17.15"
+}`
+
+func TestParseInfracost(t *testing.T) {
+	t.Parallel()
+	cost, err := ParseInfracost([]byte(infracostDiffResult))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := &Cost{
+		Currency: "USD",
+		Before:   132.85,
+		After:    115.7,
+		Delta:    -17.15,
+		Modules: []*ModuleCost{
+			{Path: "modules/vpc", Before: 32.85, After: 65.7, Delta: 65.7 - 32.85},
+			{Path: "modules/db", Before: 100, After: 50, Delta: -50},
+		},
+		Resources: []*ResourceCost{
+			{Address: "aws_nat_gateway.sub", Module: "modules/vpc", After: 32.85, Delta: 32.85},
+			{Address: "aws_db_instance.main", Module: "modules/db", Before: 100, After: 50, Delta: -50},
+		},
+	}
+	if diff := cmp.Diff(cost, exp, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+		t.Error(diff)
+	}
+
+	if _, err := ParseInfracost([]byte(`{"projects":`)); err == nil {
+		t.Error("an error must be returned for broken JSON")
+	}
+}
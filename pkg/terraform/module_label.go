@@ -0,0 +1,86 @@
+package terraform
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	texttemplate "text/template"
+
+	tmpl "github.com/mercari/tfnotify/v1/pkg/template"
+)
+
+// ParseResult returns the changes of the module as ParseResult.
+// The numbers of changes are counted like the plan summary, so a replaced resource is counted as both an addition and a destruction.
+func (mr *ModuleResult) ParseResult() ParseResult {
+	result := ParseResult{
+		CreatedResources:   mr.CreatedResources,
+		UpdatedResources:   mr.UpdatedResources,
+		DeletedResources:   mr.DeletedResources,
+		ReplacedResources:  mr.ReplacedResources,
+		MovedResources:     mr.MovedResources,
+		ImportedResources:  mr.ImportedResources,
+		ForgottenResources: mr.ForgottenResources,
+		DeferredResources:  mr.DeferredResources,
+		CreatedAddresses:   mr.CreatedAddresses,
+		UpdatedAddresses:   mr.UpdatedAddresses,
+		DeletedAddresses:   mr.DeletedAddresses,
+		ReplacedAddresses:  mr.ReplacedAddresses,
+		ImportedAddresses:  mr.ImportedAddresses,
+		ImportCount:        len(mr.ImportedResources),
+		AddCount:           len(mr.CreatedResources) + len(mr.ReplacedResources),
+		ChangeCount:        len(mr.UpdatedResources),
+		DestroyCount:       len(mr.DeletedResources) + len(mr.ReplacedResources),
+		MoveCount:          len(mr.MovedResources),
+		ForgetCount:        len(mr.ForgottenResources),
+		ModuleResults:      []*ModuleResult{mr},
+	}
+	result.HasDestroy = result.DestroyCount != 0
+	// Like PlanParser and JSONPlanParser, a module whose resources are only moved has no changes
+	result.HasNoChanges = !result.HasDestroy && result.ImportCount+result.AddCount+result.ChangeCount+result.ForgetCount == 0
+	result.HasAddOrUpdateOnly = !result.HasDestroy && !result.HasNoChanges
+	return result
+}
+
+// moduleLabelPlaceholder is the module rendered to build the pattern of module labels.
+// It must not be changed by template functions such as `base` and `replace`.
+const moduleLabelPlaceholder = "\x00"
+
+// ModuleLabel is a label rendered per Terragrunt module from a template like `{{.Module}}/destroy`
+type ModuleLabel struct {
+	tpl  *texttemplate.Template
+	vars map[string]string
+}
+
+// NewModuleLabel parses the template of a module label.
+// The template is rendered with `.Module` and `.Vars`, and must contain `.Module`.
+func NewModuleLabel(name string, vars map[string]string) (*ModuleLabel, error) {
+	tpl, err := texttemplate.New("module_label").Funcs(tmpl.TxtFuncMap()).Parse(name)
+	if err != nil {
+		return nil, fmt.Errorf("parse a template of a module label: %w", err)
+	}
+	label := &ModuleLabel{
+		tpl:  tpl,
+		vars: vars,
+	}
+	s, err := label.Render(moduleLabelPlaceholder)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.Contains(s, moduleLabelPlaceholder) {
+		return nil, errors.New("a module label must contain the module: " + name)
+	}
+	return label, nil
+}
+
+// Render returns the label of module
+func (l *ModuleLabel) Render(module string) (string, error) {
+	buf := &bytes.Buffer{}
+	if err := l.tpl.Execute(buf, map[string]any{
+		"Module": module,
+		"Vars":   l.vars,
+	}); err != nil {
+		return "", fmt.Errorf("render a module label: %w", err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,55 @@
+package terraform
+
+import (
+	"testing"
+)
+
+func TestModuleResult_ParseResult(t *testing.T) {
+	t.Parallel()
+	result := (&ModuleResult{
+		Module:            "prod/vpc",
+		CreatedResources:  []string{"aws_subnet.a"},
+		ReplacedResources: []string{"aws_subnet.b"},
+	}).ParseResult()
+	if result.AddCount != 2 || result.ChangeCount != 0 || result.DestroyCount != 1 {
+		t.Errorf("the counts are wrong: add=%d change=%d destroy=%d", result.AddCount, result.ChangeCount, result.DestroyCount)
+	}
+	if !result.HasDestroy || result.HasAddOrUpdateOnly || result.HasNoChanges {
+		t.Errorf("only HasDestroy must be true: %+v", result)
+	}
+	if len(result.ModuleResults) != 1 || result.ModuleResults[0].Module != "prod/vpc" {
+		t.Errorf("the module must be kept: %+v", result.ModuleResults)
+	}
+}
+
+func TestModuleResult_ParseResultMovesOnly(t *testing.T) {
+	t.Parallel()
+	result := (&ModuleResult{
+		Module:         "prod/vpc",
+		MovedResources: []*MovedResource{{Before: "aws_subnet.a", After: "aws_subnet.b"}},
+	}).ParseResult()
+	if result.MoveCount != 1 {
+		t.Errorf("MoveCount = %d, want 1", result.MoveCount)
+	}
+	if !result.HasNoChanges || result.HasAddOrUpdateOnly || result.HasDestroy {
+		t.Errorf("only HasNoChanges must be true: %+v", result)
+	}
+}
+
+func TestModuleLabel(t *testing.T) {
+	t.Parallel()
+	label, err := NewModuleLabel("{{.Vars.env}}:{{base .Module}}/destroy", map[string]string{"env": "prod"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, err := label.Render("stacks/vpc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "prod:vpc/destroy" {
+		t.Errorf("wanted prod:vpc/destroy, got %s", name)
+	}
+	if _, err := NewModuleLabel("destroy", nil); err == nil {
+		t.Error("a label without the module must be rejected")
+	}
+}
@@ -0,0 +1,133 @@
+package terraform
+
+import (
+	"strings"
+)
+
+// OutputChange is a change of an output value in a plan.
+// Action is one of "create", "update", and "delete".
+// Before and After are rendered in the same format as terraform plan (e.g. `"vpc-1234"`),
+// and sensitive values are `(sensitive value)`.
+// If the value spans multiple lines (e.g. a list), the lines are kept as is in After, or in Before if the output is deleted.
+type OutputChange struct {
+	Name   string
+	Action string
+	Before string
+	After  string
+}
+
+// outputChangeCollector collects OutputChange from lines of the section "Changes to Outputs:"
+type outputChangeCollector struct {
+	changes []*OutputChange
+	current *OutputChange
+	// block is the lines of the multi-line value of current
+	block   []string
+	depth   int
+	heredoc string
+}
+
+// parseOutputChanges parses the sections "Changes to Outputs:" of terraform plan.
+// Terragrunt prints the section per module, so all sections are parsed.
+func parseOutputChanges(lines []string) []*OutputChange {
+	var changes []*OutputChange
+	var c *outputChangeCollector
+	for _, line := range lines {
+		if c != nil && !c.add(line) {
+			c.flush()
+			changes = append(changes, c.changes...)
+			c = nil
+		}
+		if c == nil && strings.TrimSpace(line) == "Changes to Outputs:" {
+			c = &outputChangeCollector{}
+		}
+	}
+	if c != nil {
+		c.flush()
+		changes = append(changes, c.changes...)
+	}
+	return changes
+}
+
+// add returns false when the section ends
+func (c *outputChangeCollector) add(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	if c.heredoc != "" || c.depth > 0 {
+		c.block = append(c.block, line)
+		c.addBlockLine(trimmed)
+		return true
+	}
+	if trimmed == "" {
+		return len(c.changes) == 0
+	}
+	m := attributeChangeRe.FindStringSubmatch(trimmed)
+	if m == nil || !strings.HasPrefix(line, " ") {
+		return false
+	}
+	c.flush()
+	symbol := trimmed[:len(trimmed)-len(m[1])-1]
+	var heredoc string
+	if h := heredocStartRe.FindStringSubmatch(trimmed); h != nil {
+		heredoc = h[1]
+	}
+	opens := strings.HasSuffix(trimmed, "{") || strings.HasSuffix(trimmed, "[") || strings.HasSuffix(trimmed, "(")
+	attr := newAttributeChange(symbol, m[1], opens || heredoc != "")
+	c.current = &OutputChange{
+		Name:   attr.Name,
+		Action: outputAction(symbol),
+		Before: attr.Before,
+		After:  attr.After,
+	}
+	c.changes = append(c.changes, c.current)
+	switch {
+	case heredoc != "":
+		c.heredoc = heredoc
+	case opens:
+		c.depth = 1
+	default:
+		return true
+	}
+	// The value starts after " = "
+	_, value, _ := strings.Cut(m[1], " = ")
+	c.block = []string{value}
+	return true
+}
+
+func (c *outputChangeCollector) addBlockLine(trimmed string) {
+	if c.heredoc != "" {
+		if trimmed == c.heredoc || strings.HasPrefix(trimmed, c.heredoc+" ") {
+			c.heredoc = ""
+		}
+		return
+	}
+	if strings.HasPrefix(trimmed, "}") || strings.HasPrefix(trimmed, "]") || strings.HasPrefix(trimmed, ")") {
+		c.depth--
+	}
+	if strings.HasSuffix(trimmed, "{") || strings.HasSuffix(trimmed, "[") || strings.HasSuffix(trimmed, "(") {
+		c.depth++
+	}
+}
+
+// flush sets the multi-line value to the current output
+func (c *outputChangeCollector) flush() {
+	if c.current != nil && c.block != nil {
+		value := strings.Join(c.block, "\n")
+		if c.current.Action == "delete" {
+			c.current.Before = strings.TrimSuffix(value, " -> null")
+			c.current.After = "null"
+		} else {
+			c.current.After = value
+		}
+	}
+	c.current = nil
+	c.block = nil
+}
+
+func outputAction(symbol string) string {
+	switch symbol {
+	case "+":
+		return "create"
+	case "-":
+		return "delete"
+	}
+	return "update"
+}
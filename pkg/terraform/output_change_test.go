@@ -0,0 +1,72 @@
+package terraform
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseOutputChanges(t *testing.T) {
+	t.Parallel()
+	body := `Plan: 0 to add, 0 to change, 0 to destroy.
+
+Changes to Outputs:
+  + bucket   = (known after apply)
+  ~ instance = "t3.small" -> "t3.large"
+  - old      = "foo" -> null
+  + password = (sensitive value)
+  ~ subnets  = [
+      - "subnet-a",
+      + "subnet-b",
+    ]
+  - tags     = {
+      - env = "dev"
+    } -> null
+
+You can apply this plan to save these new output values to the Terraform
+state, without changing any real infrastructure.`
+	exp := []*OutputChange{
+		{
+			Name:   "bucket",
+			Action: "create",
+			After:  "(known after apply)",
+		},
+		{
+			Name:   "instance",
+			Action: "update",
+			Before: `"t3.small"`,
+			After:  `"t3.large"`,
+		},
+		{
+			Name:   "old",
+			Action: "delete",
+			Before: `"foo"`,
+			After:  "null",
+		},
+		{
+			Name:   "password",
+			Action: "create",
+			After:  "(sensitive value)",
+		},
+		{
+			Name:   "subnets",
+			Action: "update",
+			After: `[
+      - "subnet-a",
+      + "subnet-b",
+    ]`,
+		},
+		{
+			Name:   "tags",
+			Action: "delete",
+			Before: `{
+      - env = "dev"
+    }`,
+			After: "null",
+		},
+	}
+	if diff := cmp.Diff(exp, parseOutputChanges(strings.Split(body, "\n"))); diff != "" {
+		t.Error(diff)
+	}
+}
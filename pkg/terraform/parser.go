@@ -13,6 +13,26 @@ type Parser interface {
 	Parse(body string) ParseResult
 }
 
+// CachedParser is a Parser which returns the result of the last body without parsing it again.
+// The output of plan is parsed by every notifier and to decide the exit code, so a large plan is parsed once.
+// The result is shared, so callers must not modify the slices of the result in place.
+type CachedParser struct {
+	Parser Parser
+	body   string
+	result *ParseResult
+}
+
+// Parse returns the cached result if body is the same as the last body, and parses body with Parser otherwise
+func (p *CachedParser) Parse(body string) ParseResult {
+	if p.result != nil && p.body == body {
+		return *p.result
+	}
+	result := p.Parser.Parse(body)
+	p.body = body
+	p.result = &result
+	return result
+}
+
 // ParseResult represents the result of parsed terraform execution
 type ParseResult struct {
 	Result             string
@@ -31,12 +51,65 @@ type ParseResult struct {
 	ReplacedResources  []string
 	MovedResources     []*MovedResource
 	ImportedResources  []string
+	// ForgottenResources is the resources removed from the state without being destroyed by `removed` blocks
+	ForgottenResources []string
+	// DeferredResources is the resources whose changes are deferred to the next plan,
+	// e.g. because count or for_each is unknown
+	DeferredResources []string
+	// ReadResources is the data sources which will be read during apply
+	ReadResources []*ReadResource
+	// ImportCount and so on are the numbers of the plan summary like `Plan: 1 to import, 2 to add, 0 to change, 1 to destroy.`.
+	// MoveCount is the number of moved resources because the summary doesn't include it.
+	ImportCount  int
+	AddCount     int
+	ChangeCount  int
+	DestroyCount int
+	MoveCount    int
+	ForgetCount  int
+	// CreatedAddresses and so on are the parsed addresses of CreatedResources and so on
+	CreatedAddresses  []*ResourceAddress
+	UpdatedAddresses  []*ResourceAddress
+	DeletedAddresses  []*ResourceAddress
+	ReplacedAddresses []*ResourceAddress
+	ImportedAddresses []*ResourceAddress
+	// ResourceChanges is the attribute-level changes of created, updated, deleted, and replaced resources
+	ResourceChanges []*ResourceChange
+	// OutputChanges is the changes of output values
+	OutputChanges []*OutputChange
+	// Diagnostics is the errors and warnings reported by Terraform
+	Diagnostics []*Diagnostic
+	// UnformattedFiles is the files reported by terraform fmt -check
+	UnformattedFiles []*UnformattedFile
+	// AppliedResources is the outcome of each resource in terraform apply
+	AppliedResources []*AppliedResource
+	// DriftedResources is the resources changed outside of Terraform
+	DriftedResources []*DriftedResource
+	// TestFiles is the results of test files of terraform test
+	TestFiles []*TestFile
+	// RunURL is the URL of the run in HCP Terraform or Terraform Enterprise
+	RunURL string
+	// PolicyChecks is the Sentinel policy results of the remote run
+	PolicyChecks []*PolicyCheck
+	// CostEstimation is the cost estimation of the remote run. nil if it isn't enabled
+	CostEstimation *CostEstimation
+	// Cost is the cost estimated by Infracost. It isn't set by parsers but by notifiers from notifier.ParamExec
+	Cost *Cost
+	// Risk is the risk evaluated by RiskParser. nil if the risk isn't evaluated
+	Risk *Risk
+	// ProtectedResources is the deleted or replaced resources matching terraform.plan.protected_resources
+	ProtectedResources []string
 	// ModuleResults is populated only by TerragruntParser when Consolidated=true
 	// and the parsed body contains 2+ modules (or at least one named module).
 	// Consumer templates can use this to render a per-module Create/Update/etc
 	// summary instead of the flat global lists. Nil otherwise; templates should
 	// fall back to the flat lists when nil.
 	ModuleResults []*ModuleResult
+	// Modules is the Terragrunt modules in the output including the modules without changes.
+	// It is populated only by TerragruntParser when Consolidated=true.
+	Modules []string
+	// Parser is the kind of the output detected by DetectingParser, e.g. "terragrunt".
+	// It is empty if the result isn't parsed by DetectingParser.
+	Parser string
 }
 
 // ModuleResult is the per-module breakdown of resource changes in a
@@ -51,6 +124,13 @@ type ModuleResult struct {
 	ReplacedResources  []string
 	MovedResources     []*MovedResource
 	ImportedResources  []string
+	ForgottenResources []string
+	DeferredResources  []string
+	CreatedAddresses   []*ResourceAddress
+	UpdatedAddresses   []*ResourceAddress
+	DeletedAddresses   []*ResourceAddress
+	ReplacedAddresses  []*ResourceAddress
+	ImportedAddresses  []*ResourceAddress
 }
 
 // PlanParser is a parser for terraform plan
@@ -70,12 +150,22 @@ type PlanParser struct {
 	Import         *regexp.Regexp
 	ImportedFrom   *regexp.Regexp
 	MovedFrom      *regexp.Regexp
+	Forget         *regexp.Regexp
+	Defer          *regexp.Regexp
+	Read           *regexp.Regexp
+	ReadReason     *regexp.Regexp
+	PlanSummary    *regexp.Regexp
+	Remote         *RemoteRunParser
 }
 
 // ApplyParser is a parser for terraform apply
 type ApplyParser struct {
-	Pass *regexp.Regexp
-	Fail *regexp.Regexp
+	Pass     *regexp.Regexp
+	Fail     *regexp.Regexp
+	Start    *regexp.Regexp
+	Still    *regexp.Regexp
+	Complete *regexp.Regexp
+	Remote   *RemoteRunParser
 }
 
 // TerragruntParser is a parser for terragrunt run-all commands
@@ -95,6 +185,10 @@ type TerragruntParser struct {
 	Import         *regexp.Regexp
 	ImportedFrom   *regexp.Regexp
 	MovedFrom      *regexp.Regexp
+	Forget         *regexp.Regexp
+	Defer          *regexp.Regexp
+	Read           *regexp.Regexp
+	ReadReason     *regexp.Regexp
 	ModuleHeader   *regexp.Regexp
 	LogModule      *regexp.Regexp
 	LogRootModule  *regexp.Regexp
@@ -104,6 +198,9 @@ type TerragruntParser struct {
 	Consolidated   bool
 }
 
+// planSummaryPattern matches the plan summary like `Plan: 1 to import, 2 to add, 0 to change, 1 to destroy, 1 to forget.`
+const planSummaryPattern = `^Plan: (?:(\d+) to import, )?(\d+) to add, (\d+) to change, (\d+) to destroy(?:, (\d+) to forget)?\.`
+
 // NewPlanParser is PlanParser initialized with its Regexp
 func NewPlanParser() *PlanParser {
 	return &PlanParser{
@@ -124,6 +221,13 @@ func NewPlanParser() *PlanParser {
 		Import:        regexp.MustCompile(`^ *# (.*?) will be imported$`),
 		ImportedFrom:  regexp.MustCompile(`^ *# \(imported from (.*?)\)$`),
 		MovedFrom:     regexp.MustCompile(`^ *# \(moved from (.*?)\)$`),
+		// `removed` blocks with `lifecycle { destroy = false }`
+		Forget:      regexp.MustCompile(`^ *# (.*?) will no longer be managed by (?:Terraform|OpenTofu)$`),
+		Defer:       regexp.MustCompile(`^ *# (.*?) was deferred$`),
+		Read:        regexp.MustCompile(`^ *# (.*?) will be read during apply$`),
+		ReadReason:  regexp.MustCompile(`^ *# \((.*)\)$`),
+		PlanSummary: regexp.MustCompile(planSummaryPattern),
+		Remote:      NewRemoteRunParser(),
 	}
 }
 
@@ -132,14 +236,32 @@ func NewApplyParser() *ApplyParser {
 	return &ApplyParser{
 		Pass: regexp.MustCompile(`(?m)^(Apply complete!)`),
 		Fail: regexp.MustCompile(`(?m)^([│|╵] )?(Error: )`),
+		// e.g. `aws_instance.web: Creating...`, `aws_instance.web (deposed object 1a2b3c4d): Destroying... [id=i-1234]`
+		Start: regexp.MustCompile(`^(.+?)(?: \(deposed object \w+\))?: (Creating|Modifying|Destroying|Importing)\.\.\.(?: \[id=(.*)\])?$`),
+		// e.g. `aws_instance.web: Still modifying... [id=i-1234, 10m0s elapsed]`
+		Still: regexp.MustCompile(`^(.+?)(?: \(deposed object \w+\))?: Still (?:creating|modifying|destroying|importing)\.\.\. \[(?:id=.*, )?(\S+) elapsed\]$`),
+		// e.g. `aws_instance.web: Creation complete after 3s [id=i-1234]`
+		Complete: regexp.MustCompile(`^(.+?)(?: \(deposed object \w+\))?: (Creation|Modifications|Destruction|Import) complete after (\S+?)(?: \[id=(.*)\])?$`),
+		Remote:   NewRemoteRunParser(),
 	}
 }
 
+// Patterns of the log prefix of Terragrunt like `10:23:45.001 STDOUT [modules/vpc] tf: `.
+// They are shared by TerragruntParser and DetectingParser, so that the output detected as Terragrunt is parsed.
+const (
+	// terragruntLogTime is the timestamp of a log line
+	terragruntLogTime = `\d{2}:\d{2}:\d{2}\.\d{3}`
+	// terragruntLogLevel is the level or the stream of a log line
+	terragruntLogLevel = `(?:STDOUT|STDERR|INFO|WARN|ERROR|DEBUG)`
+	// terragruntLogTool is the program run by Terragrunt
+	terragruntLogTool = `(?:tfwrapper\.sh|terraform|tofu|tf)`
+)
+
 // NewTerragruntParser is TerragruntParser initialized with its Regexp
 // Pass consolidated=true to enable consolidated output mode for terragrunt run-all
 func NewTerragruntParser(consolidated bool) *TerragruntParser {
-	// Prefix pattern handles: HH:MM:SS.mmm (STDOUT|STDERR|INFO|ERROR) [optional-module] (tfwrapper.sh|terraform|tf):
-	prefix := `(?:\d{2}:\d{2}:\d{2}\.\d{3} (?:STDOUT|STDERR|INFO|ERROR)\s+(?:\[.*?\]\s+)?(?:tfwrapper\.sh|terraform|tf):\s*)?`
+	// Prefix pattern handles: HH:MM:SS.mmm (STDOUT|STDERR|INFO|...) [optional-module] (tfwrapper.sh|terraform|tofu|tf):
+	prefix := `(?:` + terragruntLogTime + ` ` + terragruntLogLevel + `\s+(?:\[.*?\]\s+)?` + terragruntLogTool + `:\s*)?`
 
 	return &TerragruntParser{
 		Pass:           regexp.MustCompile(`(?m)^` + prefix + `(Plan: \d|No changes\.|Apply complete!)`),
@@ -157,15 +279,19 @@ func NewTerragruntParser(consolidated bool) *TerragruntParser {
 		Import:         regexp.MustCompile(`^` + prefix + ` *# (.*?) will be imported$`),
 		ImportedFrom:   regexp.MustCompile(`^` + prefix + ` *# \(imported from (.*?)\)$`),
 		MovedFrom:      regexp.MustCompile(`^` + prefix + ` *# \(moved from (.*?)\)$`),
+		Forget:         regexp.MustCompile(`^` + prefix + ` *# (.*?) will no longer be managed by (?:Terraform|OpenTofu)$`),
+		Defer:          regexp.MustCompile(`^` + prefix + ` *# (.*?) was deferred$`),
+		Read:           regexp.MustCompile(`^` + prefix + ` *# (.*?) will be read during apply$`),
+		ReadReason:     regexp.MustCompile(`^` + prefix + ` *# \((.*)\)$`),
 		ModuleHeader:   regexp.MustCompile(`^(?:(?:Group \d+)|(?:- )?Module) (.+?)(?:\s+\[run-all\])?$`),
-		LogModule:      regexp.MustCompile(`^\d{2}:\d{2}:\d{2}\.\d{3} (?:STDOUT|STDERR|INFO|ERROR)\s+\[(.+?)\]\s`),
+		LogModule:      regexp.MustCompile(`^` + terragruntLogTime + ` ` + terragruntLogLevel + `\s+\[(.+?)\]\s`),
 		// LogRootModule: terragrunt log line for the root module — same shape
 		// as LogModule but without a [module/path] bracket. We use it to flip
 		// currentModule back to "" when the run-all output transitions from a
 		// leaf back into the root, so root resources aren't misattributed.
-		LogRootModule:  regexp.MustCompile(`^\d{2}:\d{2}:\d{2}\.\d{3} (?:STDOUT|STDERR|INFO|ERROR)\s+(?:tfwrapper\.sh|terraform|tf):`),
-		PlanSummary:    regexp.MustCompile(`^Plan: (?:(\d+) to import, )?(\d+) to add, (\d+) to change, (\d+) to destroy\.`),
-		ApplySummary:   regexp.MustCompile(`^Apply complete! Resources: (\d+) added, (\d+) changed, (\d+) destroyed\.`),
+		LogRootModule:  regexp.MustCompile(`^` + terragruntLogTime + ` ` + terragruntLogLevel + `\s+` + terragruntLogTool + `:`),
+		PlanSummary:    regexp.MustCompile(planSummaryPattern),
+		ApplySummary:   regexp.MustCompile(`^Apply complete! Resources: (?:(\d+) imported, )?(\d+) added, (\d+) changed, (\d+) destroyed\.`),
 		ActionHeader:   regexp.MustCompile(`^(?:Terraform|OpenTofu) will perform the following actions:$`),
 		Consolidated:   consolidated,
 	}
@@ -178,6 +304,42 @@ func extractResource(pattern *regexp.Regexp, line string) string {
 	return ""
 }
 
+// extractResourceChange returns the address and the action of a resource header like `# aws_instance.web will be created`
+func extractResourceChange(line string, create, update, del, replace, replaceOption *regexp.Regexp) (string, string) {
+	if rsc := extractResource(create, line); rsc != "" {
+		return rsc, "create"
+	}
+	if rsc := extractResource(update, line); rsc != "" {
+		return rsc, "update"
+	}
+	if rsc := extractResource(del, line); rsc != "" {
+		return rsc, "delete"
+	}
+	if rsc := extractResource(replace, line); rsc != "" {
+		return rsc, "replace"
+	}
+	if rsc := extractResource(replaceOption, line); rsc != "" {
+		return rsc, "replace"
+	}
+	return "", ""
+}
+
+// extractReadResource returns the data source of a line like `# data.aws_iam_policy_document.x will be read during apply`.
+// The reason is taken from the next line like `# (depends on a resource or a module with changes pending)`.
+func extractReadResource(pattern, reason *regexp.Regexp, lines []string, i int) *ReadResource {
+	rsc := extractResource(pattern, lines[i])
+	if rsc == "" {
+		return nil
+	}
+	read := &ReadResource{
+		Address: rsc,
+	}
+	if i+1 < len(lines) {
+		read.Reason = extractResource(reason, lines[i+1])
+	}
+	return read
+}
+
 func extractMovedResource(pattern *regexp.Regexp, line string) *MovedResource {
 	if arr := pattern.FindStringSubmatch(line); len(arr) == 3 { //nolint:mnd
 		return &MovedResource{
@@ -190,6 +352,7 @@ func extractMovedResource(pattern *regexp.Regexp, line string) *MovedResource {
 
 // Parse returns ParseResult related with terraform plan
 func (p *PlanParser) Parse(body string) ParseResult { //nolint:cyclop,maintidx
+	body = p.Remote.normalize(body)
 	switch {
 	case p.Fail.MatchString(body):
 	case p.Pass.MatchString(body) || p.OutputsChanges.MatchString(body):
@@ -204,7 +367,10 @@ func (p *PlanParser) Parse(body string) ParseResult { //nolint:cyclop,maintidx
 	firstMatchLineIndex := -1
 	var result, firstMatchLine string
 	var createdResources, updatedResources, deletedResources, replacedResources, importedResources []string
+	var forgottenResources, deferredResources []string
 	var movedResources []*MovedResource
+	var readResources []*ReadResource
+	resourceChanges := &resourceChangeCollector{}
 	startOutsideTerraform := -1
 	endOutsideTerraform := -1
 	startChangeOutput := -1
@@ -216,7 +382,7 @@ func (p *PlanParser) Parse(body string) ParseResult { //nolint:cyclop,maintidx
 		if line == "Note: Objects have changed outside of Terraform" || line == "Note: Objects have changed outside of OpenTofu" { // https://github.com/hashicorp/terraform/blob/332045a4e4b1d256c45f98aac74e31102ace7af7/internal/command/views/plan.go#L403
 			startOutsideTerraform = i + 1
 		}
-		if startOutsideTerraform != -1 && endOutsideTerraform == -1 && strings.HasPrefix(line, "Unless you have made equivalent changes to your configuration") { // https://github.com/hashicorp/terraform/blob/332045a4e4b1d256c45f98aac74e31102ace7af7/internal/command/views/plan.go#L110
+		if startOutsideTerraform != -1 && endOutsideTerraform == -1 && isEndOfOutsideTerraform(line) {
 			endOutsideTerraform = i + 1
 		}
 		if line == "Terraform will perform the following actions:" || line == "OpenTofu will perform the following actions:" { // https://github.com/hashicorp/terraform/blob/332045a4e4b1d256c45f98aac74e31102ace7af7/internal/command/views/plan.go#L252
@@ -252,6 +418,11 @@ func (p *PlanParser) Parse(body string) ParseResult { //nolint:cyclop,maintidx
 				firstMatchLine = line
 			}
 		}
+		if rsc, action := extractResourceChange(line, p.Create, p.Update, p.Delete, p.Replace, p.ReplaceOption); rsc != "" {
+			resourceChanges.begin(rsc, action)
+		} else {
+			resourceChanges.add(line)
+		}
 		if rsc := extractResource(p.Create, line); rsc != "" {
 			createdResources = append(createdResources, rsc)
 		} else if rsc := extractResource(p.Update, line); rsc != "" {
@@ -264,6 +435,12 @@ func (p *PlanParser) Parse(body string) ParseResult { //nolint:cyclop,maintidx
 			replacedResources = append(replacedResources, rsc)
 		} else if rsc := extractResource(p.Import, line); rsc != "" {
 			importedResources = append(importedResources, rsc)
+		} else if rsc := extractResource(p.Forget, line); rsc != "" {
+			forgottenResources = append(forgottenResources, rsc)
+		} else if rsc := extractResource(p.Defer, line); rsc != "" {
+			deferredResources = append(deferredResources, rsc)
+		} else if rsc := extractReadResource(p.Read, p.ReadReason, lines, i); rsc != nil {
+			readResources = append(readResources, rsc)
 		} else if rsc := extractResource(p.ImportedFrom, line); rsc != "" {
 			if i == 0 {
 				continue
@@ -299,10 +476,14 @@ func (p *PlanParser) Parse(body string) ParseResult { //nolint:cyclop,maintidx
 
 	hasDestroy := p.HasDestroy.MatchString(firstMatchLine)
 	hasNoChanges := p.HasNoChanges.MatchString(firstMatchLine)
+	counts := parsePlanSummary(p.PlanSummary.FindStringSubmatch(firstMatchLine))
 	HasAddOrUpdateOnly := !hasNoChanges && !hasDestroy && !hasPlanError
 
 	outsideTerraform := ""
 	if startOutsideTerraform != -1 {
+		if endOutsideTerraform == -1 {
+			endOutsideTerraform = len(lines)
+		}
 		outsideTerraform = strings.Join(lines[startOutsideTerraform:endOutsideTerraform], "\n")
 	}
 
@@ -324,7 +505,9 @@ func (p *PlanParser) Parse(body string) ParseResult { //nolint:cyclop,maintidx
 		}
 	}
 
-	return ParseResult{
+	remote := p.Remote.parse(body, lines)
+
+	return withResourceAddresses(ParseResult{
 		Result:             strings.TrimSpace(result),
 		ChangedResult:      changeResult,
 		OutsideTerraform:   outsideTerraform,
@@ -340,7 +523,55 @@ func (p *PlanParser) Parse(body string) ParseResult { //nolint:cyclop,maintidx
 		ReplacedResources:  replacedResources,
 		MovedResources:     movedResources,
 		ImportedResources:  importedResources,
+		ForgottenResources: forgottenResources,
+		DeferredResources:  deferredResources,
+		ReadResources:      readResources,
+		ImportCount:        counts.Import,
+		AddCount:           counts.Add,
+		ChangeCount:        counts.Change,
+		DestroyCount:       counts.Destroy,
+		MoveCount:          len(movedResources),
+		ForgetCount:        counts.Forget,
+		ResourceChanges:    resourceChanges.changes,
+		OutputChanges:      parseOutputChanges(lines),
+		Diagnostics:        parseDiagnostics(lines),
+		RunURL:             remote.RunURL,
+		PolicyChecks:       remote.PolicyChecks,
+		CostEstimation:     remote.CostEstimation,
+	})
+}
+
+// planCounts is the numbers of the plan summary
+type planCounts struct {
+	Import  int
+	Add     int
+	Change  int
+	Destroy int
+	Forget  int
+}
+
+// parsePlanSummary converts the submatches of planSummaryPattern to planCounts.
+// Zeros are returned if the summary isn't found.
+func parsePlanSummary(m []string) planCounts {
+	counts := planCounts{}
+	if len(m) != 6 { //nolint:mnd
+		return counts
 	}
+	// Optional groups are empty
+	counts.Import, _ = strconv.Atoi(m[1])
+	counts.Add, _ = strconv.Atoi(m[2])
+	counts.Change, _ = strconv.Atoi(m[3])
+	counts.Destroy, _ = strconv.Atoi(m[4])
+	counts.Forget, _ = strconv.Atoi(m[5])
+	return counts
+}
+
+// isEndOfOutsideTerraform returns true if the line is the last line of the section "Objects have changed outside of Terraform"
+func isEndOfOutsideTerraform(line string) bool {
+	// https://github.com/hashicorp/terraform/blob/332045a4e4b1d256c45f98aac74e31102ace7af7/internal/command/views/plan.go#L110
+	return strings.HasPrefix(line, "Unless you have made equivalent changes to your configuration") ||
+		// refresh-only plan
+		strings.HasPrefix(line, "This is a refresh-only plan, so")
 }
 
 func (p *PlanParser) changedResources(line string) string {
@@ -359,8 +590,16 @@ type MovedResource struct {
 	After  string
 }
 
+// ReadResource is a data source which will be read during apply.
+// Reason is why it can't be read during plan, e.g. `depends on a resource or a module with changes pending`.
+type ReadResource struct {
+	Address string
+	Reason  string
+}
+
 // Parse returns ParseResult related with terraform apply
 func (p *ApplyParser) Parse(body string) ParseResult {
+	body = p.Remote.normalize(body)
 	var hasError bool
 	switch {
 	case p.Fail.MatchString(body):
@@ -388,10 +627,17 @@ func (p *ApplyParser) Parse(body string) ParseResult {
 	case p.Pass.MatchString(line):
 		result = lines[i]
 	}
+	diags := parseDiagnostics(lines)
+	remote := p.Remote.parse(body, lines)
 	return ParseResult{
-		Result:   strings.TrimSpace(result),
-		HasError: hasError,
-		Error:    nil,
+		Result:           strings.TrimSpace(result),
+		HasError:         hasError,
+		Error:            nil,
+		Diagnostics:      diags,
+		AppliedResources: p.parseAppliedResources(lines, hasError, diags),
+		RunURL:           remote.RunURL,
+		PolicyChecks:     remote.PolicyChecks,
+		CostEstimation:   remote.CostEstimation,
 	}
 }
 
@@ -424,12 +670,14 @@ func (p *TerragruntParser) ParseWithConsolidation(body string, consolidated bool
 	lines := strings.Split(body, "\n")
 
 	var allCreatedResources, allUpdatedResources, allDeletedResources, allReplacedResources, allImportedResources []string
+	var allForgottenResources, allDeferredResources []string
+	var allReadResources []*ReadResource
 	var allMovedResources []*MovedResource
 	var warnings []string
 	var errorLines []string
 	hasError := false
 
-	var totalImport, totalAdd, totalChange, totalDestroy int
+	var totalImport, totalAdd, totalChange, totalDestroy, totalForget int
 	planSummaryCount := 0
 	applySummaryCount := 0
 	noChangesCount := 0
@@ -463,6 +711,10 @@ func (p *TerragruntParser) ParseWithConsolidation(body string, consolidated bool
 		return mr
 	}
 
+	// Terragrunt interleaves the output of modules, so resource bodies are tracked per module
+	var resourceChanges []*ResourceChange
+	resourceChangeCollectors := map[string]*resourceChangeCollector{}
+
 	currentModule := ""
 	// True when currentModule was last set by a [module/path] log prefix.
 	// We only honour LogRootModule (unbracketed `tf:` line) as a "switch to
@@ -470,8 +722,10 @@ func (p *TerragruntParser) ParseWithConsolidation(body string, consolidated bool
 	// to a module declared earlier via `Module <path>` would be misrouted.
 	moduleFromBracket := false
 
+	strippedLines := make([]string, len(lines))
 	for i, line := range lines {
 		stripped := stripTerragruntPrefix(line)
+		strippedLines[i] = stripped
 
 		// Determine module context. Prefer the [module/path] tag embedded in the
 		// log prefix (terragrunt run-all interleaves output of modules, and the
@@ -503,8 +757,17 @@ func (p *TerragruntParser) ParseWithConsolidation(body string, consolidated bool
 
 		// Aggregate plan/apply summaries across all modules
 		summaryLine := false
-		if m := p.PlanSummary.FindStringSubmatch(stripped); len(m) == 5 { //nolint:mnd
-			imported, _ := strconv.Atoi(m[1]) // empty when the plan has no imports
+		if m := p.PlanSummary.FindStringSubmatch(stripped); m != nil {
+			counts := parsePlanSummary(m)
+			totalImport += counts.Import
+			totalAdd += counts.Add
+			totalChange += counts.Change
+			totalDestroy += counts.Destroy
+			totalForget += counts.Forget
+			planSummaryCount++
+			summaryLine = true
+		} else if m := p.ApplySummary.FindStringSubmatch(stripped); len(m) == 5 { //nolint:mnd
+			imported, _ := strconv.Atoi(m[1]) // empty when nothing is imported
 			add, _ := strconv.Atoi(m[2])
 			change, _ := strconv.Atoi(m[3])
 			destroy, _ := strconv.Atoi(m[4])
@@ -512,15 +775,6 @@ func (p *TerragruntParser) ParseWithConsolidation(body string, consolidated bool
 			totalAdd += add
 			totalChange += change
 			totalDestroy += destroy
-			planSummaryCount++
-			summaryLine = true
-		} else if m := p.ApplySummary.FindStringSubmatch(stripped); len(m) == 4 { //nolint:mnd
-			add, _ := strconv.Atoi(m[1])
-			change, _ := strconv.Atoi(m[2])
-			destroy, _ := strconv.Atoi(m[3])
-			totalAdd += add
-			totalChange += change
-			totalDestroy += destroy
 			applySummaryCount++
 			summaryLine = true
 		} else if p.HasNoChanges.MatchString(line) {
@@ -545,7 +799,8 @@ func (p *TerragruntParser) ParseWithConsolidation(body string, consolidated bool
 				p.Create.MatchString(line) || p.Update.MatchString(line) ||
 				p.Delete.MatchString(line) || p.Replace.MatchString(line) ||
 				p.ReplaceOption.MatchString(line) || p.Import.MatchString(line) ||
-				p.Move.MatchString(line) {
+				p.Move.MatchString(line) || p.Forget.MatchString(line) ||
+				p.Defer.MatchString(line) {
 				sec.capturing = true
 				sec.lines = append(sec.lines, stripped)
 			} else if summaryLine {
@@ -556,6 +811,18 @@ func (p *TerragruntParser) ParseWithConsolidation(body string, consolidated bool
 		// Extract resources. Each match goes to both the flat global slice and
 		// the per-module bucket (the latter drives per-module rendering in
 		// consolidated mode; flat slices stay for templates that haven't migrated).
+		collector, ok := resourceChangeCollectors[currentModule]
+		if !ok {
+			collector = &resourceChangeCollector{}
+			resourceChangeCollectors[currentModule] = collector
+		}
+		if rsc, action := extractResourceChange(line, p.Create, p.Update, p.Delete, p.Replace, p.ReplaceOption); rsc != "" {
+			collector.begin(rsc, action)
+			resourceChanges = append(resourceChanges, collector.current)
+		} else {
+			collector.add(stripped)
+		}
+
 		mr := getModuleResult(currentModule)
 		if rsc := extractResource(p.Create, line); rsc != "" {
 			allCreatedResources = append(allCreatedResources, rsc)
@@ -575,6 +842,14 @@ func (p *TerragruntParser) ParseWithConsolidation(body string, consolidated bool
 		} else if rsc := extractResource(p.Import, line); rsc != "" {
 			allImportedResources = append(allImportedResources, rsc)
 			mr.ImportedResources = append(mr.ImportedResources, rsc)
+		} else if rsc := extractResource(p.Forget, line); rsc != "" {
+			allForgottenResources = append(allForgottenResources, rsc)
+			mr.ForgottenResources = append(mr.ForgottenResources, rsc)
+		} else if rsc := extractResource(p.Defer, line); rsc != "" {
+			allDeferredResources = append(allDeferredResources, rsc)
+			mr.DeferredResources = append(mr.DeferredResources, rsc)
+		} else if rsc := extractReadResource(p.Read, p.ReadReason, lines, i); rsc != nil {
+			allReadResources = append(allReadResources, rsc)
 		} else if rsc := extractResource(p.ImportedFrom, line); rsc != "" {
 			if i > 0 {
 				if toRsc := p.changedResources(lines[i-1]); toRsc != "" {
@@ -604,7 +879,7 @@ func (p *TerragruntParser) ParseWithConsolidation(body string, consolidated bool
 	// A run has no changes only when no module reported any change at all
 	hasDestroy := totalDestroy > 0
 	hasNoChanges := !hasError && applySummaryCount == 0 &&
-		totalImport == 0 && totalAdd == 0 && totalChange == 0 && totalDestroy == 0 &&
+		totalImport == 0 && totalAdd == 0 && totalChange == 0 && totalDestroy == 0 && totalForget == 0 &&
 		(noChangesCount > 0 || planSummaryCount > 0)
 
 	var result string
@@ -616,11 +891,14 @@ func (p *TerragruntParser) ParseWithConsolidation(body string, consolidated bool
 	case hasNoChanges:
 		result = "No changes. Your infrastructure matches the configuration."
 	case planSummaryCount > 0:
+		result = fmt.Sprintf("Plan: %d to add, %d to change, %d to destroy", totalAdd, totalChange, totalDestroy)
 		if totalImport > 0 {
-			result = fmt.Sprintf("Plan: %d to import, %d to add, %d to change, %d to destroy.", totalImport, totalAdd, totalChange, totalDestroy)
-		} else {
-			result = fmt.Sprintf("Plan: %d to add, %d to change, %d to destroy.", totalAdd, totalChange, totalDestroy)
+			result = fmt.Sprintf("Plan: %d to import, %d to add, %d to change, %d to destroy", totalImport, totalAdd, totalChange, totalDestroy)
+		}
+		if totalForget > 0 {
+			result += fmt.Sprintf(", %d to forget", totalForget)
 		}
+		result += "."
 	case hasOutputsChanges:
 		result = "Only Outputs will be changed."
 	}
@@ -654,7 +932,8 @@ func (p *TerragruntParser) ParseWithConsolidation(body string, consolidated bool
 		withChanges := make([]*ModuleResult, 0, len(moduleResults))
 		for _, mr := range moduleResults {
 			if len(mr.CreatedResources)+len(mr.UpdatedResources)+len(mr.DeletedResources)+
-				len(mr.ReplacedResources)+len(mr.MovedResources)+len(mr.ImportedResources) == 0 {
+				len(mr.ReplacedResources)+len(mr.MovedResources)+len(mr.ImportedResources)+
+				len(mr.ForgottenResources)+len(mr.DeferredResources) == 0 {
 				continue
 			}
 			withChanges = append(withChanges, mr)
@@ -671,8 +950,16 @@ func (p *TerragruntParser) ParseWithConsolidation(body string, consolidated bool
 			emitModuleResults = withChanges
 		}
 	}
+	var modules []string
+	if consolidated {
+		for _, mr := range moduleResults {
+			if mr.Module != "" {
+				modules = append(modules, mr.Module)
+			}
+		}
+	}
 
-	return ParseResult{
+	return withResourceAddresses(ParseResult{
 		Result:             strings.TrimSpace(result),
 		ChangedResult:      strings.Join(changeResults, "\n\n"),
 		Warning:            strings.TrimSpace(strings.Join(warnings, "\n")),
@@ -687,8 +974,21 @@ func (p *TerragruntParser) ParseWithConsolidation(body string, consolidated bool
 		ReplacedResources:  allReplacedResources,
 		MovedResources:     allMovedResources,
 		ImportedResources:  allImportedResources,
+		ForgottenResources: allForgottenResources,
+		DeferredResources:  allDeferredResources,
+		ReadResources:      allReadResources,
+		ImportCount:        totalImport,
+		AddCount:           totalAdd,
+		ChangeCount:        totalChange,
+		DestroyCount:       totalDestroy,
+		MoveCount:          len(allMovedResources),
+		ForgetCount:        totalForget,
+		ResourceChanges:    resourceChanges,
+		OutputChanges:      parseOutputChanges(strippedLines),
+		Diagnostics:        parseDiagnostics(strippedLines),
 		ModuleResults:      emitModuleResults,
-	}
+		Modules:            modules,
+	})
 }
 
 func (p *TerragruntParser) changedResources(line string) string {
@@ -728,7 +1028,7 @@ func trimBars(list []string) []string {
 // `:\s*`, the 2/4/6-space indentation of `terraform plan` was eaten too,
 // causing diff lines like `+ field = ...` to render at column 0 in the
 // rendered Change Result block.
-var terragruntPrefixRe = regexp.MustCompile(`^\d{2}:\d{2}:\d{2}\.\d{3} (?:STDOUT|STDERR|INFO|ERROR)\s+(?:\[.*?\]\s+)?(?:tfwrapper\.sh|terraform|tf): ?`)
+var terragruntPrefixRe = regexp.MustCompile(`^` + terragruntLogTime + ` ` + terragruntLogLevel + `\s+(?:\[.*?\]\s+)?` + terragruntLogTool + `: ?`)
 
 // stripTerragruntPrefix removes Terragrunt timestamp prefixes
 func stripTerragruntPrefix(line string) string {
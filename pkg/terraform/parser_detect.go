@@ -0,0 +1,81 @@
+package terraform
+
+import (
+	"regexp"
+)
+
+// Kinds of the output detected by DetectingParser.
+// The detected kind is set to ParseResult.Parser.
+const (
+	ParserKindTerraform  = "terraform"
+	ParserKindTerragrunt = "terragrunt"
+	ParserKindRemote     = "remote"
+	ParserKindJSON       = "json"
+)
+
+// DetectingParser detects the kind of the output and delegates parsing to the parser for the kind.
+// The output of HCP Terraform (Terraform Cloud) is parsed by Default, because PlanParser and ApplyParser support it.
+type DetectingParser struct {
+	Default    Parser
+	Terragrunt Parser
+	// JSON is the parser for the output of `terraform show -json`. If JSON is nil, JSON isn't detected.
+	JSON Parser
+	// TerragruntLog matches the log prefix of Terragrunt like `10:23:45.001 STDOUT [modules/vpc] tf: `
+	TerragruntLog *regexp.Regexp
+	// TerragruntHeader matches the headers of `terragrunt run-all` like `Group 1` and `- Module /repo/modules/vpc`
+	TerragruntHeader *regexp.Regexp
+	Remote           *RemoteRunParser
+}
+
+// NewDetectingPlanParser is DetectingParser for terraform plan.
+// consolidated is passed to TerragruntParser.
+func NewDetectingPlanParser(consolidated bool) *DetectingParser {
+	p := newDetectingParser(NewPlanParser(), consolidated)
+	p.JSON = NewJSONPlanParser()
+	return p
+}
+
+// NewDetectingApplyParser is DetectingParser for terraform apply.
+// consolidated is passed to TerragruntParser.
+func NewDetectingApplyParser(consolidated bool) *DetectingParser {
+	return newDetectingParser(NewApplyParser(), consolidated)
+}
+
+func newDetectingParser(defaultParser Parser, consolidated bool) *DetectingParser {
+	return &DetectingParser{
+		Default:          defaultParser,
+		Terragrunt:       NewTerragruntParser(consolidated),
+		TerragruntLog:    regexp.MustCompile(`(?m)^` + terragruntLogTime + ` ` + terragruntLogLevel + `\s+(?:\[.*?\]\s+)?` + terragruntLogTool + `: `),
+		TerragruntHeader: regexp.MustCompile(`(?m)^(?:Group \d+|- Module \S+)$`),
+		Remote:           NewRemoteRunParser(),
+	}
+}
+
+// Detect returns the kind of the output
+func (p *DetectingParser) Detect(body string) string {
+	switch {
+	case p.JSON != nil && IsJSONPlan(body):
+		return ParserKindJSON
+	case p.TerragruntLog.MatchString(body) || p.TerragruntHeader.MatchString(body):
+		return ParserKindTerragrunt
+	case p.Remote.Header.MatchString(p.Remote.Escape.ReplaceAllString(body, "")):
+		return ParserKindRemote
+	}
+	return ParserKindTerraform
+}
+
+// Parse detects the kind of the output and parses it with the parser for the kind
+func (p *DetectingParser) Parse(body string) ParseResult {
+	kind := p.Detect(body)
+	var result ParseResult
+	switch kind {
+	case ParserKindJSON:
+		result = p.JSON.Parse(body)
+	case ParserKindTerragrunt:
+		result = p.Terragrunt.Parse(body)
+	default:
+		result = p.Default.Parse(body)
+	}
+	result.Parser = kind
+	return result
+}
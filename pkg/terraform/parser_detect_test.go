@@ -0,0 +1,91 @@
+package terraform
+
+import (
+	"testing"
+)
+
+func TestDetectingParser_Detect(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name  string
+		body  string
+		apply bool
+		kind  string
+	}{
+		{
+			name: "terraform",
+			body: planSuccessResult,
+			kind: ParserKindTerraform,
+		},
+		{
+			name: "terragrunt log prefix",
+			body: "10:23:45.001 STDOUT [modules/vpc] tf: Plan: 1 to add, 0 to change, 0 to destroy.\n",
+			kind: ParserKindTerragrunt,
+		},
+		{
+			name: "terragrunt log prefix of the root module",
+			body: "10:23:45.001 STDOUT tf: Plan: 1 to add, 0 to change, 0 to destroy.\n",
+			kind: ParserKindTerragrunt,
+		},
+		{
+			name: "terragrunt running opentofu",
+			body: "10:23:45.001 STDOUT [modules/vpc] tofu: Plan: 1 to add, 0 to change, 0 to destroy.\n",
+			kind: ParserKindTerragrunt,
+		},
+		{
+			name: "terragrunt group header",
+			body: "Group 1\n- Module /repo/modules/vpc\n\nPlan: 1 to add, 0 to change, 0 to destroy.\n",
+			kind: ParserKindTerragrunt,
+		},
+		{
+			name: "hcp terraform",
+			body: "Running plan in HCP Terraform. Output will stream here. Pressing Ctrl-C\nwill stop streaming the logs, but will not stop the plan running remotely.\n\nPlan: 1 to add, 0 to change, 0 to destroy.\n",
+			kind: ParserKindRemote,
+		},
+		{
+			name: "json",
+			body: planJSONResult,
+			kind: ParserKindJSON,
+		},
+		{
+			name:  "json isn't detected for apply",
+			body:  planJSONResult,
+			apply: true,
+			kind:  ParserKindTerraform,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			parser := NewDetectingPlanParser(false)
+			if testCase.apply {
+				parser = NewDetectingApplyParser(false)
+			}
+			if kind := parser.Detect(testCase.body); kind != testCase.kind {
+				t.Errorf("wanted %q, got %q", testCase.kind, kind)
+			}
+		})
+	}
+}
+
+func TestDetectingParser_Parse(t *testing.T) {
+	t.Parallel()
+	body := `Group 1
+- Module /repo/modules/a
+- Module /repo/modules/b
+
+10:23:45.001 STDOUT [modules/a] tf:   # null_resource.a will be created
+10:23:45.001 STDOUT [modules/a] tf: Plan: 1 to add, 0 to change, 0 to destroy.
+10:23:46.001 STDOUT [modules/b] tf:   # null_resource.b will be created
+10:23:46.001 STDOUT [modules/b] tf: Plan: 1 to add, 0 to change, 0 to destroy.`
+	result := NewDetectingPlanParser(true).Parse(body)
+	if result.Parser != ParserKindTerragrunt {
+		t.Errorf("Parser: wanted %q, got %q", ParserKindTerragrunt, result.Parser)
+	}
+	if result.Result != "Plan: 2 to add, 0 to change, 0 to destroy." {
+		t.Errorf("Result: got %q", result.Result)
+	}
+	if len(result.ModuleResults) != 2 {
+		t.Errorf("ModuleResults: wanted 2 modules, got %d", len(result.ModuleResults))
+	}
+}
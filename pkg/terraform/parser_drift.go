@@ -0,0 +1,94 @@
+package terraform
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DriftedResource is a resource changed outside of Terraform
+type DriftedResource struct {
+	Address string
+	// Action is "update" if the resource has changed, and "delete" if the resource has been deleted
+	Action string
+}
+
+// DriftParser is a parser for `terraform plan -refresh-only -detailed-exitcode`
+type DriftParser struct {
+	Pass    *regexp.Regexp
+	Fail    *regexp.Regexp
+	Drift   *regexp.Regexp
+	Changed *regexp.Regexp
+	Deleted *regexp.Regexp
+}
+
+// NewDriftParser is DriftParser initialized with its Regexp
+func NewDriftParser() *DriftParser {
+	return &DriftParser{
+		Pass:    regexp.MustCompile(`(?m)^(No changes\.|Plan: \d)`),
+		Fail:    regexp.MustCompile(`(?m)^([│|╵] )?(Error: )`),
+		Drift:   regexp.MustCompile(`^Note: Objects have changed outside of (Terraform|OpenTofu)$`),
+		Changed: regexp.MustCompile(`^ *# (.*) has changed$`),
+		Deleted: regexp.MustCompile(`^ *# (.*) has been deleted$`),
+	}
+}
+
+// Parse returns ParseResult related with drift detection
+func (p *DriftParser) Parse(body string) ParseResult {
+	lines := strings.Split(body, "\n")
+	start := -1
+	end := -1
+	for i, line := range lines {
+		if p.Drift.MatchString(line) {
+			start = i + 1
+			continue
+		}
+		if start != -1 && end == -1 && isEndOfOutsideTerraform(line) {
+			end = i
+		}
+	}
+
+	hasError := p.Fail.MatchString(body)
+	if start == -1 && !hasError && !p.Pass.MatchString(body) {
+		return ParseResult{
+			Result:        "",
+			HasParseError: true,
+			Error:         errors.New("cannot parse drift result"),
+		}
+	}
+
+	outsideTerraform := ""
+	var drifted []*DriftedResource
+	if start != -1 {
+		if end == -1 {
+			end = len(lines)
+		}
+		section := lines[start:end]
+		outsideTerraform = strings.Join(section, "\n")
+		for _, line := range section {
+			if rsc := extractResource(p.Changed, line); rsc != "" {
+				drifted = append(drifted, &DriftedResource{Address: rsc, Action: "update"})
+			} else if rsc := extractResource(p.Deleted, line); rsc != "" {
+				drifted = append(drifted, &DriftedResource{Address: rsc, Action: "delete"})
+			}
+		}
+	}
+
+	result := "No drift detected."
+	switch {
+	case hasError:
+		result = "Drift detection failed."
+	case start != -1:
+		result = fmt.Sprintf("%d resource(s) have changed outside of Terraform.", len(drifted))
+	}
+	return ParseResult{
+		Result:           result,
+		OutsideTerraform: outsideTerraform,
+		HasNoChanges:     !hasError && start == -1,
+		HasError:         hasError,
+		Error:            nil,
+		Diagnostics:      parseDiagnostics(lines),
+		DriftedResources: drifted,
+	}
+}
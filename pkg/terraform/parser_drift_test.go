@@ -0,0 +1,142 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+const driftResult = `
+null_resource.foo: Refreshing state... [id=123]
+null_resource.bar: Refreshing state... [id=456]
+
+Note: Objects have changed outside of Terraform
+
+Terraform detected the following changes made outside of Terraform since the
+last "terraform apply" which may have affected this plan:
+
+  # null_resource.foo has been deleted
+  - resource "null_resource" "foo" {
+      - id = "123" -> null
+    }
+
+  # null_resource.bar has changed
+  ~ resource "null_resource" "bar" {
+        id       = "456"
+      ~ triggers = {
+          ~ "name" = "a" -> "b"
+        }
+    }
+
+This is a refresh-only plan, so Terraform will not take any actions to undo
+these. If you were expecting these changes then you can apply this plan to
+record the updated values in the Terraform state without changing any remote
+objects.
+`
+
+const noDriftResult = `
+null_resource.foo: Refreshing state... [id=123]
+
+No changes. Your infrastructure still matches the configuration.
+
+Terraform has checked that the real remote objects still match the result of
+your most recent changes, and found no differences.
+`
+
+const driftErrorResult = `
+╷
+│ Error: No valid credential sources found
+│
+│   with provider["registry.terraform.io/hashicorp/aws"],
+│   on main.tf line 1, in provider "aws":
+│    1: provider "aws" {
+│
+╵
+`
+
+func TestDriftParserParse(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name   string
+		body   string
+		result ParseResult
+	}{
+		{
+			name: "drift",
+			body: driftResult,
+			result: ParseResult{
+				Result: "2 resource(s) have changed outside of Terraform.",
+				OutsideTerraform: `
+Terraform detected the following changes made outside of Terraform since the
+last "terraform apply" which may have affected this plan:
+
+  # null_resource.foo has been deleted
+  - resource "null_resource" "foo" {
+      - id = "123" -> null
+    }
+
+  # null_resource.bar has changed
+  ~ resource "null_resource" "bar" {
+        id       = "456"
+      ~ triggers = {
+          ~ "name" = "a" -> "b"
+        }
+    }
+`,
+				DriftedResources: []*DriftedResource{
+					{
+						Address: "null_resource.foo",
+						Action:  "delete",
+					},
+					{
+						Address: "null_resource.bar",
+						Action:  "update",
+					},
+				},
+			},
+		},
+		{
+			name: "no drift",
+			body: noDriftResult,
+			result: ParseResult{
+				Result:       "No drift detected.",
+				HasNoChanges: true,
+			},
+		},
+		{
+			name: "error",
+			body: driftErrorResult,
+			result: ParseResult{
+				Result:   "Drift detection failed.",
+				HasError: true,
+				Diagnostics: []*Diagnostic{
+					{
+						Severity: DiagnosticSeverityError,
+						Summary:  "No valid credential sources found",
+						Address:  `provider["registry.terraform.io/hashicorp/aws"]`,
+						File:     "main.tf",
+						Snippet:  `   1: provider "aws" {`,
+						Line:     1,
+					},
+				},
+			},
+		},
+		{
+			name: "parse error",
+			body: "foo",
+			result: ParseResult{
+				HasParseError: true,
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			result := NewDriftParser().Parse(testCase.body)
+			if diff := cmp.Diff(testCase.result, result, cmpopts.IgnoreFields(ParseResult{}, "Error")); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
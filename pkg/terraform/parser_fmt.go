@@ -0,0 +1,118 @@
+package terraform
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// UnformattedFile is a file reported by terraform fmt -check
+type UnformattedFile struct {
+	Path string
+	// Diff is the unified diff printed with the -diff option. It is empty without -diff.
+	Diff string
+}
+
+// FmtParser is a parser for `terraform fmt -check -diff -recursive`
+type FmtParser struct {
+	File     *regexp.Regexp
+	DiffFrom *regexp.Regexp
+	Fail     *regexp.Regexp
+}
+
+// NewFmtParser is FmtParser initialized with its Regexp
+func NewFmtParser() *FmtParser {
+	return &FmtParser{
+		File:     regexp.MustCompile(`^\S+\.(tf|tfvars|tftest\.hcl|tfmock\.hcl)(\.json)?$`),
+		DiffFrom: regexp.MustCompile(`^--- old/(.+)$`),
+		Fail:     regexp.MustCompile(`(?m)^([│|╵] )?(Error: )`),
+	}
+}
+
+// Parse returns ParseResult related with terraform fmt
+func (p *FmtParser) Parse(body string) ParseResult {
+	lines := strings.Split(body, "\n")
+	var files []*UnformattedFile
+	var current *UnformattedFile
+	var diff []string
+	flush := func() {
+		if current != nil && diff != nil {
+			current.Diff = strings.TrimRight(strings.Join(diff, "\n"), "\n")
+		}
+		current = nil
+		diff = nil
+	}
+	for _, line := range lines {
+		if m := p.DiffFrom.FindStringSubmatch(line); m != nil {
+			flush()
+			current = findUnformattedFile(files, m[1])
+			if current == nil {
+				current = &UnformattedFile{Path: m[1]}
+				files = append(files, current)
+			}
+			diff = []string{line}
+			continue
+		}
+		if diff != nil && isDiffLine(line) {
+			diff = append(diff, line)
+			continue
+		}
+		flush()
+		if p.File.MatchString(line) && findUnformattedFile(files, line) == nil {
+			files = append(files, &UnformattedFile{Path: line})
+		}
+	}
+	flush()
+
+	hasError := p.Fail.MatchString(body)
+	result := "All files are formatted."
+	switch {
+	case hasError:
+		result = "terraform fmt failed."
+	case len(files) > 0:
+		result = fmt.Sprintf("%d file(s) need to be formatted.", len(files))
+	}
+	return ParseResult{
+		Result:           result,
+		HasError:         hasError,
+		Diagnostics:      parseDiagnostics(lines),
+		UnformattedFiles: files,
+	}
+}
+
+// ParseFmt parses the output of terraform fmt with parser.
+// terraform fmt -check exits with a non-zero code if files aren't formatted or it fails,
+// so the result is a parse error if the code is non-zero but neither errors nor unformatted files are found.
+func ParseFmt(parser Parser, body string, exitCode int) ParseResult {
+	result := parser.Parse(body)
+	if exitCode != 0 && !result.HasParseError && !result.HasError && len(result.UnformattedFiles) == 0 {
+		return ParseResult{
+			Result:        "",
+			HasParseError: true,
+			Error:         fmt.Errorf("cannot parse fmt result: terraform fmt exited with %d", exitCode),
+		}
+	}
+	return result
+}
+
+func findUnformattedFile(files []*UnformattedFile, path string) *UnformattedFile {
+	for _, file := range files {
+		if file.Path == path {
+			return file
+		}
+	}
+	return nil
+}
+
+// isDiffLine returns true if the line is a part of an unified diff
+func isDiffLine(line string) bool {
+	if line == "" {
+		return true
+	}
+	for _, prefix := range []string{"+++ ", "@@ ", " ", "+", "-", `\ No newline`} {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+const fmtDiffResult = `main.tf
+--- old/main.tf
++++ new/main.tf
+@@ -1,3 +1,3 @@
+ resource "null_resource" "foo" {
+-  triggers = {a="b"}
++  triggers = { a = "b" }
+ }
+modules/foo/variables.tf
+--- old/modules/foo/variables.tf
++++ new/modules/foo/variables.tf
+@@ -1,2 +1,2 @@
+-variable "name" {  }
++variable "name" {}
+ 
+`
+
+const fmtErrorResult = `
+╷
+│ Error: Invalid character
+│
+│   on main.tf line 1:
+│    1: resource "null_resource" "foo" {]
+│
+│ This character is not used within the language.
+╵
+`
+
+func TestFmtParserParse(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name   string
+		body   string
+		result ParseResult
+	}{
+		{
+			name: "formatted",
+			body: "",
+			result: ParseResult{
+				Result: "All files are formatted.",
+			},
+		},
+		{
+			name: "diff",
+			body: fmtDiffResult,
+			result: ParseResult{
+				Result: "2 file(s) need to be formatted.",
+				UnformattedFiles: []*UnformattedFile{
+					{
+						Path: "main.tf",
+						Diff: `--- old/main.tf
++++ new/main.tf
+@@ -1,3 +1,3 @@
+ resource "null_resource" "foo" {
+-  triggers = {a="b"}
++  triggers = { a = "b" }
+ }`,
+					},
+					{
+						Path: "modules/foo/variables.tf",
+						Diff: `--- old/modules/foo/variables.tf
++++ new/modules/foo/variables.tf
+@@ -1,2 +1,2 @@
+-variable "name" {  }
++variable "name" {}
+ `,
+					},
+				},
+			},
+		},
+		{
+			name: "list only",
+			body: "main.tf\nterraform.tfvars\n",
+			result: ParseResult{
+				Result: "2 file(s) need to be formatted.",
+				UnformattedFiles: []*UnformattedFile{
+					{Path: "main.tf"},
+					{Path: "terraform.tfvars"},
+				},
+			},
+		},
+		{
+			name: "error",
+			body: fmtErrorResult,
+			result: ParseResult{
+				Result:   "terraform fmt failed.",
+				HasError: true,
+				Diagnostics: []*Diagnostic{
+					{
+						Severity: DiagnosticSeverityError,
+						Summary:  "Invalid character",
+						Detail:   "This character is not used within the language.",
+						File:     "main.tf",
+						Line:     1,
+						Snippet:  `   1: resource "null_resource" "foo" {]`,
+					},
+				},
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			result := NewFmtParser().Parse(testCase.body)
+			if diff := cmp.Diff(testCase.result, result, cmpopts.IgnoreFields(ParseResult{}, "Error")); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestParseFmt(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name          string
+		body          string
+		exitCode      int
+		hasParseError bool
+	}{
+		{
+			name: "formatted",
+		},
+		{
+			name:     "unformatted",
+			body:     fmtDiffResult,
+			exitCode: 3,
+		},
+		{
+			name:     "error",
+			body:     fmtErrorResult,
+			exitCode: 2,
+		},
+		{
+			name:          "unknown failure",
+			body:          "Usage: terraform [global options] fmt [options] [target...]\n",
+			exitCode:      1,
+			hasParseError: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			result := ParseFmt(NewFmtParser(), testCase.body, testCase.exitCode)
+			if result.HasParseError != testCase.hasParseError {
+				t.Errorf("HasParseError: wanted %v, got %v", testCase.hasParseError, result.HasParseError)
+			}
+		})
+	}
+}
@@ -0,0 +1,447 @@
+package terraform
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// JSONPlanParser is a parser for the machine-readable plan printed by
+// `terraform show -json <plan file>`. Unlike PlanParser it doesn't depend on
+// the wording of the human readable output, so it keeps working when
+// Terraform rewords a line.
+type JSONPlanParser struct{}
+
+// NewJSONPlanParser is JSONPlanParser initializer
+func NewJSONPlanParser() *JSONPlanParser {
+	return &JSONPlanParser{}
+}
+
+// jsonPlan is the subset of the JSON plan representation used by tfnotify.
+// https://developer.hashicorp.com/terraform/internals/json-format#plan-representation
+type jsonPlan struct {
+	FormatVersion   string                 `json:"format_version"`
+	ResourceDrift   []*jsonResourceChange  `json:"resource_drift"`
+	ResourceChanges []*jsonResourceChange  `json:"resource_changes"`
+	DeferredChanges []*jsonDeferredChange  `json:"deferred_changes"`
+	OutputChanges   map[string]*jsonChange `json:"output_changes"`
+	Errored         bool                   `json:"errored"`
+}
+
+// jsonDeferredChange is a change deferred to the next plan, e.g. because count or for_each is unknown
+type jsonDeferredChange struct {
+	Reason         string              `json:"reason"`
+	ResourceChange *jsonResourceChange `json:"resource_change"`
+}
+
+type jsonResourceChange struct {
+	Address         string     `json:"address"`
+	PreviousAddress string     `json:"previous_address"`
+	ModuleAddress   string     `json:"module_address"`
+	Mode            string     `json:"mode"`
+	Type            string     `json:"type"`
+	Name            string     `json:"name"`
+	ProviderName    string     `json:"provider_name"`
+	Change          jsonChange `json:"change"`
+	ActionReason    string     `json:"action_reason"`
+}
+
+type jsonChange struct {
+	Actions         []string       `json:"actions"`
+	Before          any            `json:"before"`
+	After           any            `json:"after"`
+	AfterUnknown    any            `json:"after_unknown"`
+	BeforeSensitive any            `json:"before_sensitive"`
+	AfterSensitive  any            `json:"after_sensitive"`
+	ReplacePaths    [][]any        `json:"replace_paths"`
+	Importing       *jsonImporting `json:"importing"`
+}
+
+type jsonImporting struct {
+	ID string `json:"id"`
+}
+
+// findJSONPlan returns the JSON plan embedded in body.
+func findJSONPlan(body string) (string, bool) {
+	return findJSON(body, "format_version")
+}
+
+// findJSON returns the JSON document containing all fields embedded in body.
+// Lines before the JSON document (e.g. printed by wrapper scripts) are ignored.
+func findJSON(body string, fields ...string) (string, bool) {
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		if !strings.HasPrefix(strings.TrimSpace(line), "{") {
+			continue
+		}
+		s := strings.TrimSpace(strings.Join(lines[i:], "\n"))
+		if !slices.ContainsFunc(fields, func(field string) bool {
+			return !strings.Contains(s, `"`+field+`"`)
+		}) {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+// IsJSONPlan returns true if body looks like the output of `terraform show -json`
+func IsJSONPlan(body string) bool {
+	_, ok := findJSONPlan(body)
+	return ok
+}
+
+// Parse returns ParseResult related with `terraform show -json`
+func (p *JSONPlanParser) Parse(body string) ParseResult { //nolint:cyclop
+	s, ok := findJSONPlan(body)
+	if !ok {
+		return ParseResult{
+			Result:        "",
+			HasParseError: true,
+			Error:         errors.New("cannot parse plan result: JSON plan isn't found"),
+		}
+	}
+	plan := &jsonPlan{}
+	if err := json.NewDecoder(strings.NewReader(s)).Decode(plan); err != nil {
+		return ParseResult{
+			Result:        "",
+			HasParseError: true,
+			Error:         fmt.Errorf("cannot parse plan result: %w", err),
+		}
+	}
+
+	var createdResources, updatedResources, deletedResources, replacedResources, importedResources []string
+	var forgottenResources, deferredResources []string
+	var movedResources []*MovedResource
+	var readResources []*ReadResource
+	var resourceChanges []*ResourceChange
+	var changes []string
+	providers := map[string]string{}
+	for _, rc := range plan.ResourceChanges {
+		if rc.ProviderName != "" {
+			providers[rc.Address] = jsonProvider(rc.ProviderName)
+		}
+		if rc.PreviousAddress != "" && rc.PreviousAddress != rc.Address {
+			movedResources = append(movedResources, &MovedResource{
+				Before: rc.PreviousAddress,
+				After:  rc.Address,
+			})
+		}
+		if rc.Change.Importing != nil {
+			importedResources = append(importedResources, rc.Address)
+		}
+		action := jsonAction(rc.Change.Actions)
+		switch action {
+		case "create", "update", "delete", "replace":
+			resourceChanges = append(resourceChanges, newJSONResourceChange(rc.Address, action, &rc.Change))
+		}
+		switch action {
+		case "create":
+			createdResources = append(createdResources, rc.Address)
+			changes = append(changes, "  # "+rc.Address+" will be created")
+		case "update":
+			updatedResources = append(updatedResources, rc.Address)
+			changes = append(changes, "  # "+rc.Address+" will be updated in-place")
+		case "delete":
+			deletedResources = append(deletedResources, rc.Address)
+			changes = append(changes, "  # "+rc.Address+" will be destroyed")
+		case "replace":
+			replacedResources = append(replacedResources, rc.Address)
+			changes = append(changes, "  # "+rc.Address+" must be replaced")
+		case "read":
+			readResources = append(readResources, &ReadResource{
+				Address: rc.Address,
+				Reason:  jsonReadReasons[rc.ActionReason],
+			})
+			changes = append(changes, "  # "+rc.Address+" will be read during apply")
+		case "forget":
+			forgottenResources = append(forgottenResources, rc.Address)
+			changes = append(changes, "  # "+rc.Address+" will no longer be managed by Terraform")
+		case "no-op":
+			switch {
+			case rc.Change.Importing != nil:
+				changes = append(changes, "  # "+rc.Address+" will be imported")
+			case rc.PreviousAddress != "" && rc.PreviousAddress != rc.Address:
+				changes = append(changes, "  # "+rc.PreviousAddress+" has moved to "+rc.Address)
+			}
+		}
+	}
+
+	for _, dc := range plan.DeferredChanges {
+		if dc.ResourceChange == nil {
+			continue
+		}
+		deferredResources = append(deferredResources, dc.ResourceChange.Address)
+		changes = append(changes, "  # "+dc.ResourceChange.Address+" was deferred")
+	}
+
+	var outputs []string
+	var outputChanges []*OutputChange
+	for _, name := range slices.Sorted(maps.Keys(plan.OutputChanges)) {
+		change := plan.OutputChanges[name]
+		action := jsonAction(change.Actions)
+		switch action {
+		case "create":
+			outputs = append(outputs, "  + "+name)
+		case "update":
+			outputs = append(outputs, "  ~ "+name)
+		case "delete":
+			outputs = append(outputs, "  - "+name)
+		default:
+			continue
+		}
+		outputChanges = append(outputChanges, newJSONOutputChange(name, action, change))
+	}
+
+	var drifts []string
+	for _, rc := range plan.ResourceDrift {
+		if jsonAction(rc.Change.Actions) == "delete" {
+			drifts = append(drifts, "  # "+rc.Address+" has been deleted")
+			continue
+		}
+		drifts = append(drifts, "  # "+rc.Address+" has changed")
+	}
+
+	addCount := len(createdResources) + len(replacedResources)
+	changeCount := len(updatedResources)
+	destroyCount := len(deletedResources) + len(replacedResources)
+	importCount := len(importedResources)
+	forgetCount := len(forgottenResources)
+
+	var result string
+	hasNoChanges := false
+	switch {
+	case plan.Errored:
+		result = "Planning failed. Terraform encountered an error while generating this plan."
+	case addCount+changeCount+destroyCount+importCount+forgetCount > 0:
+		result = fmt.Sprintf("Plan: %d to add, %d to change, %d to destroy", addCount, changeCount, destroyCount)
+		if importCount > 0 {
+			result = fmt.Sprintf("Plan: %d to import, %d to add, %d to change, %d to destroy", importCount, addCount, changeCount, destroyCount)
+		}
+		if forgetCount > 0 {
+			result += fmt.Sprintf(", %d to forget", forgetCount)
+		}
+		result += "."
+	case len(outputs) > 0:
+		result = "Only Outputs will be changed."
+	case len(movedResources) > 0:
+		// Terraform prints the summary even if resources are only moved
+		result = "Plan: 0 to add, 0 to change, 0 to destroy."
+		hasNoChanges = true
+	default:
+		result = "No changes. Your infrastructure matches the configuration."
+		hasNoChanges = true
+	}
+
+	changeResult := strings.Join(changes, "\n")
+	if len(outputs) > 0 {
+		if changeResult != "" {
+			changeResult += "\n\n"
+		}
+		changeResult += "Changes to Outputs:\n" + strings.Join(outputs, "\n")
+	}
+
+	hasDestroy := destroyCount > 0
+
+	ret := withResourceAddresses(ParseResult{
+		Result:             result,
+		ChangedResult:      changeResult,
+		OutsideTerraform:   strings.Join(drifts, "\n"),
+		HasAddOrUpdateOnly: !hasNoChanges && !hasDestroy && !plan.Errored,
+		HasDestroy:         hasDestroy,
+		HasNoChanges:       hasNoChanges,
+		HasError:           plan.Errored,
+		Error:              nil,
+		CreatedResources:   createdResources,
+		UpdatedResources:   updatedResources,
+		DeletedResources:   deletedResources,
+		ReplacedResources:  replacedResources,
+		MovedResources:     movedResources,
+		ImportedResources:  importedResources,
+		ForgottenResources: forgottenResources,
+		DeferredResources:  deferredResources,
+		ReadResources:      readResources,
+		ImportCount:        importCount,
+		AddCount:           addCount,
+		ChangeCount:        changeCount,
+		DestroyCount:       destroyCount,
+		MoveCount:          len(movedResources),
+		ForgetCount:        forgetCount,
+		ResourceChanges:    resourceChanges,
+		OutputChanges:      outputChanges,
+	})
+	// The provider is known from the JSON plan, e.g. `google-beta` for `google_` resources
+	for _, addrs := range [][]*ResourceAddress{ret.CreatedAddresses, ret.UpdatedAddresses, ret.DeletedAddresses, ret.ReplacedAddresses, ret.ImportedAddresses} {
+		for _, addr := range addrs {
+			if provider, ok := providers[addr.Address]; ok {
+				addr.Provider = provider
+			}
+		}
+	}
+	return ret
+}
+
+// jsonProvider returns the type of a provider, e.g. `aws` of `registry.terraform.io/hashicorp/aws`
+func jsonProvider(name string) string {
+	return name[strings.LastIndex(name, "/")+1:]
+}
+
+// jsonReadReasons maps action_reason of data sources to the reasons printed by terraform plan
+var jsonReadReasons = map[string]string{
+	"read_because_config_unknown":     "config refers to values not yet known",
+	"read_because_dependency_pending": "depends on a resource or a module with changes pending",
+	"read_because_check_nested":       "config will be reloaded to verify a check block",
+}
+
+// jsonAction converts the actions of a JSON plan change to a single action.
+// ["delete", "create"] and ["create", "delete"] are converted to "replace".
+func jsonAction(actions []string) string {
+	switch len(actions) {
+	case 0:
+		return ""
+	case 1:
+		return actions[0]
+	default:
+		return "replace"
+	}
+}
+
+// newJSONResourceChange compares top-level attributes of before and after.
+// Values are rendered like the human readable plan.
+func newJSONResourceChange(address, action string, change *jsonChange) *ResourceChange {
+	rc := &ResourceChange{
+		Address: address,
+		Action:  action,
+	}
+	before, _ := change.Before.(map[string]any)
+	after, _ := change.After.(map[string]any)
+	keys := map[string]struct{}{}
+	for k := range before {
+		keys[k] = struct{}{}
+	}
+	for k := range after {
+		keys[k] = struct{}{}
+	}
+	if m, ok := change.AfterUnknown.(map[string]any); ok {
+		for k := range m {
+			keys[k] = struct{}{}
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(keys)) {
+		b, a := before[key], after[key]
+		unknown := jsonAttributeFlag(change.AfterUnknown, key)
+		if !unknown && reflect.DeepEqual(b, a) {
+			continue
+		}
+		attr := &AttributeChange{
+			Name:   key,
+			Before: jsonAttributeValue(b, jsonAttributeFlag(change.BeforeSensitive, key)),
+			After:  jsonAttributeValue(a, jsonAttributeFlag(change.AfterSensitive, key)),
+		}
+		if unknown {
+			attr.After = knownAfterApply
+		}
+		if b == nil {
+			// e.g. `+ name = "foo"`
+			attr.Before = ""
+		}
+		rc.ChangedAttributes = append(rc.ChangedAttributes, attr)
+	}
+	for _, path := range change.ReplacePaths {
+		if len(path) == 0 {
+			continue
+		}
+		if name, ok := path[0].(string); ok && !slices.Contains(rc.ForcesReplacement, name) {
+			rc.ForcesReplacement = append(rc.ForcesReplacement, name)
+		}
+	}
+	return rc
+}
+
+// newJSONOutputChange renders the values of an output like the human readable plan
+func newJSONOutputChange(name, action string, change *jsonChange) *OutputChange {
+	oc := &OutputChange{
+		Name:   name,
+		Action: action,
+	}
+	if action != "create" {
+		oc.Before = jsonAttributeValue(change.Before, jsonAttributeFlag(change.BeforeSensitive, ""))
+	}
+	switch {
+	case action == "delete":
+		oc.After = "null"
+	case jsonAttributeFlag(change.AfterUnknown, ""):
+		oc.After = knownAfterApply
+	default:
+		oc.After = jsonAttributeValue(change.After, jsonAttributeFlag(change.AfterSensitive, ""))
+	}
+	return oc
+}
+
+// jsonAttributeFlag returns true if the attribute is marked in after_unknown, before_sensitive, or after_sensitive.
+func jsonAttributeFlag(v any, key string) bool {
+	switch m := v.(type) {
+	case bool:
+		return m
+	case map[string]any:
+		b, _ := m[key].(bool)
+		return b
+	}
+	return false
+}
+
+func jsonAttributeValue(v any, sensitive bool) string {
+	if sensitive {
+		return sensitiveValue
+	}
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Sprint(v)
+	}
+	return strings.TrimSpace(buf.String())
+}
+
+// PlanFileParser parses the output of `terraform show` with Parser and merges PlanJSON, the output of `terraform show -json`, into the result.
+// PlanJSON is set after the plan file is shown. Wrappers such as RiskParser wrap PlanFileParser, so that they evaluate the merged result.
+type PlanFileParser struct {
+	Parser   Parser
+	PlanJSON string
+}
+
+// Parse parses body with Parser and merges PlanJSON into the result if it is set
+func (p *PlanFileParser) Parse(body string) ParseResult {
+	result := p.Parser.Parse(body)
+	if p.PlanJSON == "" {
+		return result
+	}
+	return MergeJSONPlan(result, NewJSONPlanParser().Parse(p.PlanJSON))
+}
+
+// MergeJSONPlan merges the result of `terraform show -json` into the result of `terraform show` for the same plan file.
+// The human readable output is kept for ChangedResult and so on,
+// and the structured values which are known only from the JSON plan such as ResourceChanges are taken from jsonResult.
+// If result has a parse error, jsonResult is returned.
+func MergeJSONPlan(result, jsonResult ParseResult) ParseResult {
+	if jsonResult.HasParseError {
+		return result
+	}
+	if result.HasParseError {
+		jsonResult.Parser = ParserKindJSON
+		return jsonResult
+	}
+	result.CreatedAddresses = jsonResult.CreatedAddresses
+	result.UpdatedAddresses = jsonResult.UpdatedAddresses
+	result.DeletedAddresses = jsonResult.DeletedAddresses
+	result.ReplacedAddresses = jsonResult.ReplacedAddresses
+	result.ImportedAddresses = jsonResult.ImportedAddresses
+	result.ResourceChanges = jsonResult.ResourceChanges
+	result.OutputChanges = jsonResult.OutputChanges
+	result.ReadResources = jsonResult.ReadResources
+	return result
+}
@@ -0,0 +1,308 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+const planJSONResult = `{"format_version":"1.2","terraform_version":"1.9.5","resource_drift":[{"address":"null_resource.drift","mode":"managed","type":"null_resource","name":"drift","provider_name":"registry.terraform.io/hashicorp/null","change":{"actions":["update"]}}],"resource_changes":[{"address":"null_resource.create","mode":"managed","type":"null_resource","name":"create","provider_name":"registry.terraform.io/hashicorp/null","change":{"actions":["create"],"before":null,"after":{"triggers":null},"after_unknown":{"id":true}}},{"address":"null_resource.update","mode":"managed","type":"null_resource","name":"update","provider_name":"registry.terraform.io/hashicorp/null","change":{"actions":["update"],"before":{"id":"1","triggers":{"a":"1"}},"after":{"id":"1","triggers":{"a":"2"}}}},{"address":"null_resource.delete","mode":"managed","type":"null_resource","name":"delete","provider_name":"registry.terraform.io/hashicorp/null","change":{"actions":["delete"],"before":{"id":"3"},"after":null}},{"address":"module.foo.null_resource.replace","module_address":"module.foo","mode":"managed","type":"null_resource","name":"replace","provider_name":"registry.terraform.io/hashicorp/null","change":{"actions":["delete","create"],"before":{"id":"2","name":"a","password":"x"},"after":{"name":"b","password":"y"},"after_unknown":{"id":true},"before_sensitive":{"password":true},"after_sensitive":{"password":true},"replace_paths":[["name"]]},"action_reason":"replace_because_tainted"},{"address":"null_resource.bar","previous_address":"null_resource.foo","mode":"managed","type":"null_resource","name":"bar","provider_name":"registry.terraform.io/hashicorp/null","change":{"actions":["no-op"]}},{"address":"github_repository.tfnotify","mode":"managed","type":"github_repository","name":"tfnotify","provider_name":"registry.terraform.io/integrations/github","change":{"actions":["no-op"],"importing":{"id":"tfnotify"}}},{"address":"null_resource.noop","mode":"managed","type":"null_resource","name":"noop","provider_name":"registry.terraform.io/hashicorp/null","change":{"actions":["no-op"]}}],"output_changes":{"name":{"actions":["create"]}}}`
+
+const planJSONOnlyOutputsResult = `{"format_version":"1.2","output_changes":{"name":{"actions":["update"],"before":"a","after":"b"},"secret":{"actions":["update"],"before":"x","after":"y","before_sensitive":true,"after_sensitive":true},"unchanged":{"actions":["no-op"]}}}`
+
+const planJSONForgetResult = `{"format_version":"1.2","resource_changes":[{"address":"null_resource.legacy","mode":"managed","type":"null_resource","name":"legacy","change":{"actions":["forget"],"before":{"id":"1"},"after":null}}],"deferred_changes":[{"reason":"instance_count_unknown","resource_change":{"address":"null_resource.workers","mode":"managed","type":"null_resource","name":"workers","change":{"actions":["create"]}}}]}`
+
+const planJSONReadResult = `{"format_version":"1.2","resource_changes":[{"address":"data.aws_iam_policy_document.assume","mode":"data","type":"aws_iam_policy_document","name":"assume","change":{"actions":["read"]},"action_reason":"read_because_dependency_pending"}]}`
+
+const planJSONNoChangesResult = `{"format_version":"1.2","resource_changes":[{"address":"null_resource.noop","mode":"managed","type":"null_resource","name":"noop","change":{"actions":["no-op"]}}],"output_changes":{"name":{"actions":["no-op"]}}}`
+
+func TestJSONPlanParserParse(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name   string
+		body   string
+		result ParseResult
+	}{
+		{
+			name: "resource changes",
+			body: "terraform show -json tfplan\n" + planJSONResult + "\n",
+			result: ParseResult{
+				Result:             "Plan: 1 to import, 2 to add, 1 to change, 2 to destroy.",
+				ImportCount:        1,
+				AddCount:           2,
+				ChangeCount:        1,
+				DestroyCount:       2,
+				MoveCount:          1,
+				HasAddOrUpdateOnly: false,
+				HasDestroy:         true,
+				HasNoChanges:       false,
+				HasError:           false,
+				ChangedResult: `  # null_resource.create will be created
+  # null_resource.update will be updated in-place
+  # null_resource.delete will be destroyed
+  # module.foo.null_resource.replace must be replaced
+  # null_resource.foo has moved to null_resource.bar
+  # github_repository.tfnotify will be imported
+
+Changes to Outputs:
+  + name`,
+				OutsideTerraform:  "  # null_resource.drift has changed",
+				CreatedResources:  []string{"null_resource.create"},
+				UpdatedResources:  []string{"null_resource.update"},
+				DeletedResources:  []string{"null_resource.delete"},
+				ReplacedResources: []string{"module.foo.null_resource.replace"},
+				MovedResources: []*MovedResource{
+					{
+						Before: "null_resource.foo",
+						After:  "null_resource.bar",
+					},
+				},
+				ImportedResources: []string{"github_repository.tfnotify"},
+				OutputChanges: []*OutputChange{
+					{
+						Name:   "name",
+						Action: "create",
+						After:  "null",
+					},
+				},
+				CreatedAddresses: []*ResourceAddress{
+					{
+						Address:  "null_resource.create",
+						Mode:     "managed",
+						Type:     "null_resource",
+						Name:     "create",
+						Provider: "null",
+					},
+				},
+				UpdatedAddresses: []*ResourceAddress{
+					{
+						Address:  "null_resource.update",
+						Mode:     "managed",
+						Type:     "null_resource",
+						Name:     "update",
+						Provider: "null",
+					},
+				},
+				DeletedAddresses: []*ResourceAddress{
+					{
+						Address:  "null_resource.delete",
+						Mode:     "managed",
+						Type:     "null_resource",
+						Name:     "delete",
+						Provider: "null",
+					},
+				},
+				ReplacedAddresses: []*ResourceAddress{
+					{
+						Address:    "module.foo.null_resource.replace",
+						ModulePath: "module.foo",
+						Mode:       "managed",
+						Type:       "null_resource",
+						Name:       "replace",
+						Provider:   "null",
+					},
+				},
+				ImportedAddresses: []*ResourceAddress{
+					{
+						Address:  "github_repository.tfnotify",
+						Mode:     "managed",
+						Type:     "github_repository",
+						Name:     "tfnotify",
+						Provider: "github",
+					},
+				},
+				ResourceChanges: []*ResourceChange{
+					{
+						Address: "null_resource.create",
+						Action:  "create",
+						ChangedAttributes: []*AttributeChange{
+							{Name: "id", After: "(known after apply)"},
+						},
+					},
+					{
+						Address: "null_resource.update",
+						Action:  "update",
+						ChangedAttributes: []*AttributeChange{
+							{Name: "triggers", Before: `{"a":"1"}`, After: `{"a":"2"}`},
+						},
+					},
+					{
+						Address: "null_resource.delete",
+						Action:  "delete",
+						ChangedAttributes: []*AttributeChange{
+							{Name: "id", Before: `"3"`, After: "null"},
+						},
+					},
+					{
+						Address: "module.foo.null_resource.replace",
+						Action:  "replace",
+						ChangedAttributes: []*AttributeChange{
+							{Name: "id", Before: `"2"`, After: "(known after apply)"},
+							{Name: "name", Before: `"a"`, After: `"b"`},
+							{Name: "password", Before: "(sensitive value)", After: "(sensitive value)"},
+						},
+						ForcesReplacement: []string{"name"},
+					},
+				},
+			},
+		},
+		{
+			name: "only outputs",
+			body: planJSONOnlyOutputsResult,
+			result: ParseResult{
+				Result:             "Only Outputs will be changed.",
+				HasAddOrUpdateOnly: true,
+				ChangedResult: `Changes to Outputs:
+  ~ name
+  ~ secret`,
+				OutputChanges: []*OutputChange{
+					{
+						Name:   "name",
+						Action: "update",
+						Before: `"a"`,
+						After:  `"b"`,
+					},
+					{
+						Name:   "secret",
+						Action: "update",
+						Before: "(sensitive value)",
+						After:  "(sensitive value)",
+					},
+				},
+			},
+		},
+		{
+			name: "forget and deferred",
+			body: planJSONForgetResult,
+			result: ParseResult{
+				Result:             "Plan: 0 to add, 0 to change, 0 to destroy, 1 to forget.",
+				ForgetCount:        1,
+				HasAddOrUpdateOnly: true,
+				ChangedResult: `  # null_resource.legacy will no longer be managed by Terraform
+  # null_resource.workers was deferred`,
+				ForgottenResources: []string{"null_resource.legacy"},
+				DeferredResources:  []string{"null_resource.workers"},
+			},
+		},
+		{
+			name: "read during apply",
+			body: planJSONReadResult,
+			result: ParseResult{
+				Result:        "No changes. Your infrastructure matches the configuration.",
+				HasNoChanges:  true,
+				ChangedResult: "  # data.aws_iam_policy_document.assume will be read during apply",
+				ReadResources: []*ReadResource{
+					{
+						Address: "data.aws_iam_policy_document.assume",
+						Reason:  "depends on a resource or a module with changes pending",
+					},
+				},
+			},
+		},
+		{
+			name: "no changes",
+			body: planJSONNoChangesResult,
+			result: ParseResult{
+				Result:       "No changes. Your infrastructure matches the configuration.",
+				HasNoChanges: true,
+			},
+		},
+		{
+			name: "errored",
+			body: `{"format_version":"1.2","errored":true}`,
+			result: ParseResult{
+				Result:   "Planning failed. Terraform encountered an error while generating this plan.",
+				HasError: true,
+			},
+		},
+		{
+			name: "not json",
+			body: planSuccessResult,
+			result: ParseResult{
+				HasParseError: true,
+			},
+		},
+		{
+			name: "broken json",
+			body: `{"format_version":"1.2",`,
+			result: ParseResult{
+				HasParseError: true,
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			parser := NewJSONPlanParser()
+			result := parser.Parse(testCase.body)
+			if diff := cmp.Diff(result, testCase.result, cmpopts.IgnoreFields(ParseResult{}, "Error")); diff != "" {
+				t.Error(diff)
+			}
+			if result.HasParseError && result.Error == nil {
+				t.Error("Error must be set when HasParseError is true")
+			}
+		})
+	}
+}
+
+func TestIsJSONPlan(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name string
+		body string
+		exp  bool
+	}{
+		{
+			name: "json plan",
+			body: planJSONResult,
+			exp:  true,
+		},
+		{
+			name: "json plan after other lines",
+			body: "Running terraform show -json\n" + planJSONResult,
+			exp:  true,
+		},
+		{
+			name: "text plan",
+			body: planSuccessResult,
+		},
+		{
+			name: "other json",
+			body: `{"valid": true}`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			if got := IsJSONPlan(testCase.body); got != testCase.exp {
+				t.Errorf("IsJSONPlan() = %v, want %v", got, testCase.exp)
+			}
+		})
+	}
+}
+
+func TestMergeJSONPlan(t *testing.T) {
+	t.Parallel()
+	jsonResult := NewJSONPlanParser().Parse(planJSONResult)
+	result := MergeJSONPlan(NewPlanParser().Parse(planSuccessResult), jsonResult)
+	if result.ChangedResult == jsonResult.ChangedResult {
+		t.Error("ChangedResult must be the human readable output")
+	}
+	if diff := cmp.Diff(result.ResourceChanges, jsonResult.ResourceChanges); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff(result.CreatedAddresses, jsonResult.CreatedAddresses); diff != "" {
+		t.Error(diff)
+	}
+
+	result = MergeJSONPlan(NewPlanParser().Parse("foo"), jsonResult)
+	if result.HasParseError || result.Parser != ParserKindJSON {
+		t.Errorf("the JSON plan must be used if the human readable output can't be parsed: %+v", result)
+	}
+
+	text := NewPlanParser().Parse(planSuccessResult)
+	if diff := cmp.Diff(MergeJSONPlan(text, NewJSONPlanParser().Parse("foo")), text, cmpopts.IgnoreFields(ParseResult{}, "Error")); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -1,8 +1,11 @@
 package terraform
 
 import (
+	"slices"
 	"strings"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestTerragruntParser_Parse(t *testing.T) {
@@ -35,6 +38,21 @@ func TestTerragruntParser_Parse(t *testing.T) {
 			wantHasNoChanges: false,
 			wantCreatedCount: 1,
 		},
+		{
+			name: "opentofu module",
+			input: `10:23:45.001 STDOUT [modules/vpc] tofu: OpenTofu will perform the following actions:
+10:23:45.001 STDOUT [modules/vpc] tofu:
+10:23:45.001 STDOUT [modules/vpc] tofu:   # null_resource.test will be destroyed
+10:23:45.001 STDOUT [modules/vpc] tofu:   - resource "null_resource" "test" {
+10:23:45.001 STDOUT [modules/vpc] tofu:       - id = "1234" -> null
+10:23:45.001 STDOUT [modules/vpc] tofu:     }
+10:23:45.001 STDOUT [modules/vpc] tofu:
+10:23:45.001 STDOUT [modules/vpc] tofu: Plan: 0 to add, 0 to change, 1 to destroy.`,
+			wantHasError:     false,
+			wantHasDestroy:   true,
+			wantHasNoChanges: false,
+			wantDeletedCount: 1,
+		},
 		{
 			name: "multiple modules with run-all",
 			input: `Group 1
@@ -283,6 +301,10 @@ func TestTerragruntParser_ConsolidationMixedNoChanges(t *testing.T) {
 	if !strings.Contains(result.ChangedResult, "Plan: 1 to add, 0 to change, 0 to destroy.") {
 		t.Errorf("ChangedResult missing plan summary:\n%s", result.ChangedResult)
 	}
+	// Modules without changes are tracked as well
+	if !slices.Equal(result.Modules, []string{"cluster/shared-vpc", "cluster/cluster"}) {
+		t.Errorf("Modules = %v, want both modules", result.Modules)
+	}
 }
 
 func TestTerragruntParser_ConsolidationAggregatesTotals(t *testing.T) {
@@ -400,6 +422,33 @@ Group 2
 	}
 }
 
+func TestTerragruntParser_ConsolidatedForgottenAndDeferred(t *testing.T) {
+	parser := NewTerragruntParser(true)
+
+	input := `10:23:45.001 STDOUT [modules/a] tf:   # aws_instance.legacy will no longer be managed by Terraform
+10:23:45.001 STDOUT [modules/a] tf: Plan: 0 to add, 0 to change, 0 to destroy, 1 to forget.
+10:24:05.222 STDOUT [modules/b] tf:   # aws_instance.workers["a"] was deferred
+10:24:05.222 STDOUT [modules/b] tf: Plan: 0 to add, 0 to change, 0 to destroy.`
+
+	result := parser.Parse(input)
+
+	if diff := cmp.Diff([]string{"aws_instance.legacy"}, result.ForgottenResources); diff != "" {
+		t.Errorf("ForgottenResources: %s", diff)
+	}
+	if diff := cmp.Diff([]string{`aws_instance.workers["a"]`}, result.DeferredResources); diff != "" {
+		t.Errorf("DeferredResources: %s", diff)
+	}
+	if len(result.ModuleResults) != 2 {
+		t.Fatalf("ModuleResults length = %d, want 2:\n%+v", len(result.ModuleResults), result.ModuleResults)
+	}
+	if diff := cmp.Diff([]string{"aws_instance.legacy"}, result.ModuleResults[0].ForgottenResources); diff != "" {
+		t.Errorf("ModuleResults[0].ForgottenResources: %s", diff)
+	}
+	if diff := cmp.Diff([]string{`aws_instance.workers["a"]`}, result.ModuleResults[1].DeferredResources); diff != "" {
+		t.Errorf("ModuleResults[1].DeferredResources: %s", diff)
+	}
+}
+
 func TestTerragruntParser_ConsolidatedNotEmittedForSingleUnnamed(t *testing.T) {
 	parser := NewTerragruntParser(true)
 
@@ -506,6 +555,26 @@ func TestTerragruntParser_PlanSummaryWithImports(t *testing.T) {
 	}
 }
 
+func TestTerragruntParser_Counts(t *testing.T) {
+	t.Parallel()
+	parser := NewTerragruntParser(true)
+
+	input := `09:32:46.963 STDOUT [vpc] terraform:   # null_resource.a has moved to null_resource.b
+09:32:46.963 STDOUT [vpc] terraform: Plan: 1 to import, 2 to add, 0 to change, 1 to destroy.
+09:32:46.964 STDOUT [iam] terraform: Plan: 0 to add, 3 to change, 2 to destroy, 1 to forget.`
+
+	result := parser.Parse(input)
+
+	exp := []int{1, 2, 3, 3, 1, 1}
+	got := []int{result.ImportCount, result.AddCount, result.ChangeCount, result.DestroyCount, result.MoveCount, result.ForgetCount}
+	if diff := cmp.Diff(exp, got); diff != "" {
+		t.Errorf("ImportCount, AddCount, ChangeCount, DestroyCount, MoveCount, ForgetCount: %s", diff)
+	}
+	if result.Result != "Plan: 1 to import, 2 to add, 3 to change, 3 to destroy, 1 to forget." {
+		t.Errorf("Result = %q", result.Result)
+	}
+}
+
 func TestTerragruntParser_ApplyConsolidated(t *testing.T) {
 	parser := NewTerragruntParser(true)
 
@@ -561,6 +630,11 @@ func TestStripTerragruntPrefix(t *testing.T) {
 			input: "18:14:11.466 STDOUT [cluster-citadel-2g/regions/tokyo/cluster] tfwrapper.sh: Plan: 1 to add, 0 to change, 0 to destroy.",
 			want:  "Plan: 1 to add, 0 to change, 0 to destroy.",
 		},
+		{
+			name:  "with tofu",
+			input: "10:23:45.001 WARN [modules/vpc] tofu: Warning: Deprecated attribute",
+			want:  "Warning: Deprecated attribute",
+		},
 		{
 			name:  "without prefix",
 			input: "Plan: 1 to add",
@@ -582,3 +656,70 @@ func TestStripTerragruntPrefix(t *testing.T) {
 		})
 	}
 }
+
+func TestTerragruntParser_ResourceChanges(t *testing.T) {
+	t.Parallel()
+	parser := NewTerragruntParser(true)
+
+	// Resource bodies of modules are interleaved, so they must be tracked per module.
+	input := `10:23:45.001 STDOUT [vpc] tf:   # aws_vpc.main will be updated in-place
+10:23:45.001 STDOUT [vpc] tf:   ~ resource "aws_vpc" "main" {
+10:23:45.002 STDOUT [iam] tf:   # aws_iam_role.ci must be replaced
+10:23:45.002 STDOUT [iam] tf: -/+ resource "aws_iam_role" "ci" {
+10:23:45.002 STDOUT [iam] tf:       ~ name = "ci" -> "ci-2" # forces replacement
+10:23:45.001 STDOUT [vpc] tf:       ~ tags = {
+10:23:45.001 STDOUT [vpc] tf:           ~ "Name" = "main" -> "primary"
+10:23:45.001 STDOUT [vpc] tf:         }
+10:23:45.002 STDOUT [iam] tf:     }
+10:23:45.001 STDOUT [vpc] tf:       ~ cidr_block = "10.0.0.0/16" -> "10.1.0.0/16"
+10:23:45.001 STDOUT [vpc] tf:     }
+10:23:45.002 STDOUT [iam] tf: Plan: 1 to add, 0 to change, 1 to destroy.
+10:23:45.001 STDOUT [vpc] tf: Plan: 0 to add, 1 to change, 0 to destroy.`
+
+	result := parser.Parse(input)
+
+	exp := []*ResourceChange{
+		{
+			Address: "aws_vpc.main",
+			Action:  "update",
+			ChangedAttributes: []*AttributeChange{
+				{Name: "tags"},
+				{Name: "cidr_block", Before: `"10.0.0.0/16"`, After: `"10.1.0.0/16"`},
+			},
+		},
+		{
+			Address: "aws_iam_role.ci",
+			Action:  "replace",
+			ChangedAttributes: []*AttributeChange{
+				{Name: "name", Before: `"ci"`, After: `"ci-2"`},
+			},
+			ForcesReplacement: []string{"name"},
+		},
+	}
+	if diff := cmp.Diff(exp, result.ResourceChanges); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestTerragruntParser_Diagnostics(t *testing.T) {
+	t.Parallel()
+	input := `10:23:45.001 STDOUT [vpc] tf: No changes. Your infrastructure matches the configuration.
+10:23:45.001 STDERR [vpc] tf: ╷
+10:23:45.001 STDERR [vpc] tf: │ Warning: Argument is deprecated
+10:23:45.001 STDERR [vpc] tf: │ 
+10:23:45.001 STDERR [vpc] tf: │ Use the aws_s3_bucket_acl resource instead
+10:23:45.001 STDERR [vpc] tf: ╵`
+
+	result := NewTerragruntParser(false).Parse(input)
+
+	exp := []*Diagnostic{
+		{
+			Severity: "warning",
+			Summary:  "Argument is deprecated",
+			Detail:   "Use the aws_s3_bucket_acl resource instead",
+		},
+	}
+	if diff := cmp.Diff(exp, result.Diagnostics); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -519,6 +519,7 @@ func TestPlanParserParse(t *testing.T) { //nolint:maintidx
 			body: planSuccessResult,
 			result: ParseResult{
 				Result:             "Plan: 1 to add, 0 to change, 0 to destroy.",
+				AddCount:           1,
 				HasAddOrUpdateOnly: true,
 				HasDestroy:         false,
 				HasNoChanges:       false,
@@ -552,6 +553,13 @@ Plan: 0 to add, 0 to change, 0 to destroy.
 
 Changes to Outputs:
   + aws_instance_name = "my-instance"`,
+				OutputChanges: []*OutputChange{
+					{
+						Name:   "aws_instance_name",
+						Action: "create",
+						After:  `"my-instance"`,
+					},
+				},
 			},
 		},
 		{
@@ -569,6 +577,13 @@ Changes to Outputs:
 
 You can apply this plan to save these new output values to the Terraform
 state, without changing any real infrastructure.`,
+				OutputChanges: []*OutputChange{
+					{
+						Name:   "test",
+						Action: "create",
+						After:  "42",
+					},
+				},
 			},
 		},
 		{
@@ -586,6 +601,13 @@ state, without changing any real infrastructure.`,
 
 You can apply this plan to save these new output values to the Terraform
 state, without changing any real infrastructure.`,
+				OutputChanges: []*OutputChange{
+					{
+						Name:   "test",
+						Action: "create",
+						After:  "42",
+					},
+				},
 			},
 		},
 		{
@@ -616,6 +638,16 @@ state, without changing any real infrastructure.`,
 				HasNoChanges:       false,
 				HasError:           true,
 				Error:              nil,
+				Diagnostics: []*Diagnostic{
+					{
+						Severity: "error",
+						Summary:  "Error refreshing state: 4 error(s) occurred:",
+						Detail: `* google_sql_database.main: 1 error(s) occurred:
+
+* google_sql_database.main: google_sql_database.main: Error reading SQL Database "main" in instance "main-master-instance": googleapi: Error 409: The instance or operation is not in an appropriate state to handle the request., invalidState
+* google_sql_user.proxyuser_main: 1 error(s) occurred:`,
+					},
+				},
 			},
 		},
 		{
@@ -635,6 +667,7 @@ state, without changing any real infrastructure.`,
 			body: planHasDestroy,
 			result: ParseResult{
 				Result:             "Plan: 0 to add, 0 to change, 1 to destroy.",
+				DestroyCount:       1,
 				HasAddOrUpdateOnly: false,
 				HasDestroy:         true,
 				HasNoChanges:       false,
@@ -652,6 +685,8 @@ Plan: 0 to add, 0 to change, 1 to destroy.`,
 			body: planHasAddAndDestroy,
 			result: ParseResult{
 				Result:             "Plan: 1 to add, 0 to change, 1 to destroy.",
+				AddCount:           1,
+				DestroyCount:       1,
 				HasAddOrUpdateOnly: false,
 				HasDestroy:         true,
 				HasNoChanges:       false,
@@ -676,6 +711,8 @@ Plan: 1 to add, 0 to change, 1 to destroy.`,
 			body: planHasAddAndUpdateInPlace,
 			result: ParseResult{
 				Result:             "Plan: 1 to add, 1 to change, 0 to destroy.",
+				AddCount:           1,
+				ChangeCount:        1,
 				HasAddOrUpdateOnly: true,
 				ChangedResult: `
   + google_compute_global_address.my_another_project
@@ -696,6 +733,11 @@ Plan: 1 to add, 1 to change, 0 to destroy.`,
 			body: planImportedMovedResourceChanged,
 			result: ParseResult{
 				Result:             "Plan: 1 to import, 2 to add, 2 to change, 1 to destroy.",
+				ImportCount:        1,
+				AddCount:           2,
+				ChangeCount:        2,
+				DestroyCount:       1,
+				MoveCount:          3,
 				HasAddOrUpdateOnly: false,
 				HasDestroy:         true,
 				HasNoChanges:       false,
@@ -728,6 +770,103 @@ Plan: 1 to add, 1 to change, 0 to destroy.`,
 				ImportedResources: []string{
 					"github_repository.tfnotify",
 				},
+				CreatedAddresses: []*ResourceAddress{
+					{
+						Address:  "null_resource.zoo",
+						Mode:     "managed",
+						Type:     "null_resource",
+						Name:     "zoo",
+						Provider: "null",
+					},
+				},
+				UpdatedAddresses: []*ResourceAddress{
+					{
+						Address:  "github_repository.tfaction-2",
+						Mode:     "managed",
+						Type:     "github_repository",
+						Name:     "tfaction-2",
+						Provider: "github",
+					},
+					{
+						Address:  "github_repository.tfnotify",
+						Mode:     "managed",
+						Type:     "github_repository",
+						Name:     "tfnotify",
+						Provider: "github",
+					},
+				},
+				ReplacedAddresses: []*ResourceAddress{
+					{
+						Address:  "github_issue.test-2",
+						Mode:     "managed",
+						Type:     "github_issue",
+						Name:     "test-2",
+						Provider: "github",
+					},
+				},
+				ImportedAddresses: []*ResourceAddress{
+					{
+						Address:  "github_repository.tfnotify",
+						Mode:     "managed",
+						Type:     "github_repository",
+						Name:     "tfnotify",
+						Provider: "github",
+					},
+				},
+				ResourceChanges: []*ResourceChange{
+					{
+						Address: "github_issue.test-2",
+						Action:  "replace",
+						ChangedAttributes: []*AttributeChange{
+							{Name: "assignees", Before: "[]", After: "null"},
+							{Name: "etag", Before: `"W/\"e14116be2014dddd5d766ba8d69e59524491648d626917f4dbe8d5422ef32ba3\""`, After: "(known after apply)"},
+							{Name: "id", Before: `"tfaction:902"`, After: "(known after apply)"},
+							{Name: "issue_id", Before: "1685922946", After: "(known after apply)"},
+							{Name: "labels"},
+							{Name: "milestone_number", Before: "0", After: "null"},
+							{Name: "number", Before: "902", After: "(known after apply)"},
+							{Name: "repository", Before: `"tfaction"`, After: `"tfnotify"`},
+						},
+						ForcesReplacement: []string{"repository"},
+					},
+					{
+						Address: "github_repository.tfaction-2",
+						Action:  "update",
+						ChangedAttributes: []*AttributeChange{
+							{Name: "allow_auto_merge", Before: "true", After: "false"},
+							{Name: "allow_update_branch", Before: "true", After: "null"},
+							{Name: "delete_branch_on_merge", Before: "true", After: "false"},
+							{Name: "description", Before: `"Framework for Monorepo to build high level Terraform Workflows by GitHub Actions"`, After: "null"},
+							{Name: "full_name", Before: `"mercari/tfaction"`, After: "(known after apply)"},
+							{Name: "has_discussions", Before: "true", After: "null"},
+							{Name: "has_downloads", Before: "true", After: "null"},
+							{Name: "has_issues", Before: "true", After: "null"},
+							{Name: "has_projects", Before: "true", After: "null"},
+							{Name: "homepage_url", Before: `"https://mercari.github.io/tfaction/docs/"`, After: "null"},
+							{Name: "name", Before: `"tfaction"`, After: `"action"`},
+							{Name: "vulnerability_alerts", Before: "true", After: "null"},
+							{Name: "pages"},
+						},
+					},
+					{
+						Address: "github_repository.tfnotify",
+						Action:  "update",
+						ChangedAttributes: []*AttributeChange{
+							{
+								Name:   "description",
+								Before: `"Fork of mercari/tfnotify. tfnotify enhances tfnotify in many ways, including Terraform >= v0.15 support and advanced formatting options"`,
+								After:  `"Fork of mercari/tfnotify. tfnotify enhances tfnotify in many ways, including Terraform >= v0.15 support and advanced formatting"`,
+							},
+						},
+					},
+					{
+						Address: "null_resource.zoo",
+						Action:  "create",
+						ChangedAttributes: []*AttributeChange{
+							{Name: "id", After: "(known after apply)"},
+						},
+					},
+				},
 				ChangedResult: `
   # github_issue.test-2 must be replaced
   # (moved from github_issue.test)
@@ -868,6 +1007,101 @@ Plan: 1 to import, 2 to add, 2 to change, 1 to destroy.`,
 	}
 }
 
+func TestPlanParserParseForget(t *testing.T) {
+	t.Parallel()
+	result := NewPlanParser().Parse("Plan: 0 to add, 0 to change, 0 to destroy, 2 to forget.\n")
+	if result.HasNoChanges {
+		t.Error("HasNoChanges = true, want false")
+	}
+	if result.ForgetCount != 2 {
+		t.Errorf("ForgetCount = %d, want 2", result.ForgetCount)
+	}
+}
+
+func TestPlanParserParseForgottenAndDeferred(t *testing.T) {
+	t.Parallel()
+	body := `Terraform will perform the following actions:
+
+  # aws_instance.legacy will no longer be managed by Terraform
+  . resource "aws_instance" "legacy" {
+        id = "i-1234"
+    }
+
+  # terraform_data.old will no longer be managed by OpenTofu
+  . resource "terraform_data" "old" {
+        id = "a-b-c"
+    }
+
+  # aws_s3_bucket.logs will be created
+  + resource "aws_s3_bucket" "logs" {
+      + bucket = "logs"
+    }
+
+The following actions were deferred:
+
+  # aws_instance.workers["a"] was deferred
+  # (because the number of resource instances is unknown)
+
+Plan: 1 to add, 0 to change, 0 to destroy, 2 to forget.
+`
+	result := NewPlanParser().Parse(body)
+	if diff := cmp.Diff([]string{"aws_instance.legacy", "terraform_data.old"}, result.ForgottenResources); diff != "" {
+		t.Errorf("ForgottenResources: %s", diff)
+	}
+	if diff := cmp.Diff([]string{`aws_instance.workers["a"]`}, result.DeferredResources); diff != "" {
+		t.Errorf("DeferredResources: %s", diff)
+	}
+	if diff := cmp.Diff([]string{"aws_s3_bucket.logs"}, result.CreatedResources); diff != "" {
+		t.Errorf("CreatedResources: %s", diff)
+	}
+	if result.ForgetCount != 2 {
+		t.Errorf("ForgetCount = %d, want 2", result.ForgetCount)
+	}
+}
+
+func TestPlanParserParseReadResources(t *testing.T) {
+	t.Parallel()
+	body := `Terraform will perform the following actions:
+
+  # data.aws_iam_policy_document.assume will be read during apply
+  # (depends on a resource or a module with changes pending)
+ <= data "aws_iam_policy_document" "assume" {
+      + id   = (known after apply)
+      + json = (known after apply)
+    }
+
+  # data.http.health will be read during apply
+  # (config refers to values not yet known)
+ <= data "http" "health" {
+      + url = (known after apply)
+    }
+
+  # aws_iam_role.this will be updated in-place
+  ~ resource "aws_iam_role" "this" {
+      ~ assume_role_policy = jsonencode({}) -> (known after apply)
+    }
+
+Plan: 0 to add, 1 to change, 0 to destroy.
+`
+	result := NewPlanParser().Parse(body)
+	want := []*ReadResource{
+		{
+			Address: "data.aws_iam_policy_document.assume",
+			Reason:  "depends on a resource or a module with changes pending",
+		},
+		{
+			Address: "data.http.health",
+			Reason:  "config refers to values not yet known",
+		},
+	}
+	if diff := cmp.Diff(want, result.ReadResources); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff([]string{"aws_iam_role.this"}, result.UpdatedResources); diff != "" {
+		t.Errorf("UpdatedResources: %s", diff)
+	}
+}
+
 func TestApplyParserParse(t *testing.T) {
 	t.Parallel()
 	testCases := []struct {
@@ -902,6 +1136,15 @@ func TestApplyParserParse(t *testing.T) {
    6: resource "google_project_service" "gcp_api_service" {`,
 				Error:    nil,
 				HasError: true,
+				Diagnostics: []*Diagnostic{
+					{
+						Severity: "error",
+						Summary:  `Batch "project/tfnotify-jp-tfnotify-prod/services:batchEnable" for request "Enable Project Services tfnotify-jp-tfnotify-prod: map[logging.googleapis.com:{}]" returned error: failed to send enable services request: googleapi: Error 403: The caller does not have permission, forbidden`,
+						File:     ".terraform/modules/tfnotify-jp-tfnotify-prod/google_project_service.tf",
+						Line:     6,
+						Snippet:  `   6: resource "google_project_service" "gcp_api_service" {`,
+					},
+				},
 			},
 		},
 	}
@@ -954,3 +1197,26 @@ func TestTrimLastNewline(t *testing.T) {
 		}
 	}
 }
+
+type countParser struct {
+	count int
+}
+
+func (p *countParser) Parse(body string) ParseResult {
+	p.count++
+	return ParseResult{Result: body}
+}
+
+func TestCachedParserParse(t *testing.T) {
+	t.Parallel()
+	counter := &countParser{}
+	parser := &CachedParser{Parser: counter}
+	for _, body := range []string{"a", "a", "b", "b", "a"} {
+		if result := parser.Parse(body); result.Result != body {
+			t.Errorf("wanted %q, got %q", body, result.Result)
+		}
+	}
+	if counter.count != 3 {
+		t.Errorf("the body must be parsed only when it changes: parsed %d times", counter.count)
+	}
+}
@@ -0,0 +1,116 @@
+package terraform
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// TestFile is the result of a test file of terraform test.
+// Status is one of "pass", "fail", "skip", and "error".
+type TestFile struct {
+	Path   string
+	Status string
+	Runs   []*TestRun
+}
+
+// TestRun is the result of a run block of a test file.
+// Status is one of "pass", "fail", "skip", and "error".
+type TestRun struct {
+	Name   string
+	Status string
+	// Diagnostics is the errors and warnings printed for the run block, e.g. failed assertions
+	Diagnostics []*Diagnostic
+}
+
+// TestParser is a parser for `terraform test` and `tofu test`
+type TestParser struct {
+	File    *regexp.Regexp
+	Run     *regexp.Regexp
+	Summary *regexp.Regexp
+	Fail    *regexp.Regexp
+}
+
+// NewTestParser is TestParser initialized with its Regexp
+func NewTestParser() *TestParser {
+	return &TestParser{
+		// e.g. `tests/main.tftest.hcl... pass` and `tests/main.tftest.hcl... in progress`
+		File: regexp.MustCompile(`^(\S+\.tftest\.(?:hcl|json))\.\.\. (pass|fail|skip|error|in progress|tearing down)$`),
+		// e.g. `  run "setup"... pass`
+		Run: regexp.MustCompile(`^\s+run "(.+)"\.\.\. (pass|fail|skip|error)$`),
+		// e.g. `Success! 2 passed, 0 failed.`, `Failure! 1 passed, 1 failed, 1 skipped.`, and `Executed 0 tests.`
+		Summary: regexp.MustCompile(`(?m)^(?:(?:Success|Failure)! \d+ passed, \d+ failed(?:, \d+ skipped)?\.|Executed 0 tests(?:, \d+ errored)?\.)`),
+		Fail:    regexp.MustCompile(`(?m)^([│|╵] )?(Error: )`),
+	}
+}
+
+// Parse returns ParseResult related with terraform test
+func (p *TestParser) Parse(body string) ParseResult {
+	lines := strings.Split(body, "\n")
+	var files []*TestFile
+	var file *TestFile
+	var run *TestRun
+	// runLines is the output of run, which contains the diagnostics of run
+	var runLines []string
+	flush := func() {
+		if run != nil {
+			run.Diagnostics = parseDiagnostics(runLines)
+		}
+		run = nil
+		runLines = nil
+	}
+	for _, line := range lines {
+		if m := p.File.FindStringSubmatch(line); m != nil {
+			flush()
+			if file == nil || file.Path != m[1] {
+				file = &TestFile{Path: m[1]}
+				files = append(files, file)
+			}
+			switch m[2] {
+			case "in progress", "tearing down":
+			default:
+				file.Status = m[2]
+			}
+			continue
+		}
+		if m := p.Run.FindStringSubmatch(line); m != nil && file != nil {
+			flush()
+			run = &TestRun{
+				Name:   m[1],
+				Status: m[2],
+			}
+			file.Runs = append(file.Runs, run)
+			continue
+		}
+		if run != nil {
+			runLines = append(runLines, line)
+		}
+	}
+	flush()
+
+	summary := p.Summary.FindString(body)
+	if summary == "" && len(files) == 0 && !p.Fail.MatchString(body) {
+		return ParseResult{
+			Result:        "",
+			HasParseError: true,
+			Error:         errors.New("cannot parse test result"),
+		}
+	}
+
+	hasError := strings.HasPrefix(summary, "Failure!") || p.Fail.MatchString(body)
+	for _, file := range files {
+		if file.Status == "fail" || file.Status == "error" {
+			hasError = true
+		}
+	}
+	result := summary
+	if result == "" && hasError {
+		result = "terraform test failed."
+	}
+	return ParseResult{
+		Result:      result,
+		HasError:    hasError,
+		Diagnostics: parseDiagnostics(lines),
+		TestFiles:   files,
+	}
+}
@@ -0,0 +1,142 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+const testSuccessResult = `tests/main.tftest.hcl... in progress
+  run "setup"... pass
+  run "validate_name"... pass
+tests/main.tftest.hcl... tearing down
+tests/main.tftest.hcl... pass
+
+Success! 2 passed, 0 failed.
+`
+
+const testFailureResult = `tests/main.tftest.hcl... in progress
+  run "setup"... pass
+  run "validate_name"... fail
+╷
+│ Error: Test assertion failed
+│
+│   on tests/main.tftest.hcl line 12, in run "validate_name":
+│   12:     condition     = aws_s3_bucket.this.bucket == "foo"
+│
+│ Bucket name is wrong
+╵
+  run "cleanup"... skip
+tests/main.tftest.hcl... tearing down
+tests/main.tftest.hcl... fail
+tests/other.tftest.hcl... in progress
+  run "a"... pass
+tests/other.tftest.hcl... tearing down
+tests/other.tftest.hcl... pass
+
+Failure! 2 passed, 1 failed, 1 skipped.
+`
+
+func TestTestParserParse(t *testing.T) {
+	t.Parallel()
+	assertion := &Diagnostic{
+		Severity: DiagnosticSeverityError,
+		Summary:  "Test assertion failed",
+		Detail:   "Bucket name is wrong",
+		File:     "tests/main.tftest.hcl",
+		Line:     12,
+		Snippet:  `  12:     condition     = aws_s3_bucket.this.bucket == "foo"`,
+	}
+	testCases := []struct {
+		name   string
+		body   string
+		result ParseResult
+	}{
+		{
+			name: "success",
+			body: testSuccessResult,
+			result: ParseResult{
+				Result: "Success! 2 passed, 0 failed.",
+				TestFiles: []*TestFile{
+					{
+						Path:   "tests/main.tftest.hcl",
+						Status: "pass",
+						Runs: []*TestRun{
+							{Name: "setup", Status: "pass"},
+							{Name: "validate_name", Status: "pass"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "failure",
+			body: testFailureResult,
+			result: ParseResult{
+				Result:      "Failure! 2 passed, 1 failed, 1 skipped.",
+				HasError:    true,
+				Diagnostics: []*Diagnostic{assertion},
+				TestFiles: []*TestFile{
+					{
+						Path:   "tests/main.tftest.hcl",
+						Status: "fail",
+						Runs: []*TestRun{
+							{Name: "setup", Status: "pass"},
+							{Name: "validate_name", Status: "fail", Diagnostics: []*Diagnostic{assertion}},
+							{Name: "cleanup", Status: "skip"},
+						},
+					},
+					{
+						Path:   "tests/other.tftest.hcl",
+						Status: "pass",
+						Runs: []*TestRun{
+							{Name: "a", Status: "pass"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "no test",
+			body: "Executed 0 tests.\n",
+			result: ParseResult{
+				Result: "Executed 0 tests.",
+			},
+		},
+		{
+			name: "error",
+			body: fmtErrorResult,
+			result: ParseResult{
+				Result:   "terraform test failed.",
+				HasError: true,
+				Diagnostics: []*Diagnostic{
+					{
+						Severity: DiagnosticSeverityError,
+						Summary:  "Invalid character",
+						Detail:   "This character is not used within the language.",
+						File:     "main.tf",
+						Line:     1,
+						Snippet:  `   1: resource "null_resource" "foo" {]`,
+					},
+				},
+			},
+		},
+		{
+			name: "parse error",
+			body: "foo",
+			result: ParseResult{
+				HasParseError: true,
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			result := NewTestParser().Parse(testCase.body)
+			if diff := cmp.Diff(testCase.result, result, cmpopts.IgnoreFields(ParseResult{}, "Error")); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package terraform
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ValidateParser is a parser for terraform validate.
+// Both the output of `terraform validate -json` and the human readable output are supported.
+type ValidateParser struct {
+	Pass *regexp.Regexp
+	Fail *regexp.Regexp
+}
+
+// NewValidateParser is ValidateParser initialized with its Regexp
+func NewValidateParser() *ValidateParser {
+	return &ValidateParser{
+		Pass: regexp.MustCompile(`(?m)^Success! The configuration is valid`),
+		Fail: regexp.MustCompile(`(?m)^([│|╵] )?(Error: )`),
+	}
+}
+
+// jsonValidateResult is the output of `terraform validate -json`.
+// https://developer.hashicorp.com/terraform/cli/commands/validate#json
+type jsonValidateResult struct {
+	Valid       bool              `json:"valid"`
+	Diagnostics []*jsonDiagnostic `json:"diagnostics"`
+}
+
+type jsonDiagnostic struct {
+	Severity string               `json:"severity"`
+	Summary  string               `json:"summary"`
+	Detail   string               `json:"detail"`
+	Range    *jsonDiagnosticRange `json:"range"`
+	Snippet  *jsonSnippet         `json:"snippet"`
+}
+
+type jsonDiagnosticRange struct {
+	Filename string       `json:"filename"`
+	Start    jsonPosition `json:"start"`
+}
+
+type jsonPosition struct {
+	Line int `json:"line"`
+}
+
+type jsonSnippet struct {
+	Code      string `json:"code"`
+	StartLine int    `json:"start_line"`
+}
+
+// Parse returns ParseResult related with terraform validate
+func (p *ValidateParser) Parse(body string) ParseResult {
+	// `terraform validate -json` prints format_version like `terraform show -json`
+	if s, ok := findJSON(body, "format_version", "valid"); ok {
+		return p.parseJSON(s)
+	}
+	switch {
+	case p.Fail.MatchString(body):
+	case p.Pass.MatchString(body):
+	default:
+		return ParseResult{
+			Result:        "",
+			HasParseError: true,
+			Error:         errors.New("cannot parse validate result"),
+		}
+	}
+	diags := parseDiagnostics(strings.Split(body, "\n"))
+	return newValidateResult(!p.Fail.MatchString(body), diags)
+}
+
+func (p *ValidateParser) parseJSON(s string) ParseResult {
+	result := &jsonValidateResult{}
+	if err := json.NewDecoder(strings.NewReader(s)).Decode(result); err != nil {
+		return ParseResult{
+			Result:        "",
+			HasParseError: true,
+			Error:         fmt.Errorf("cannot parse validate result: %w", err),
+		}
+	}
+	diags := make([]*Diagnostic, len(result.Diagnostics))
+	for i, d := range result.Diagnostics {
+		diag := &Diagnostic{
+			Severity: d.Severity,
+			Summary:  d.Summary,
+			Detail:   d.Detail,
+		}
+		if d.Range != nil {
+			diag.File = d.Range.Filename
+			diag.Line = d.Range.Start.Line
+		}
+		if d.Snippet != nil {
+			diag.Snippet = numberSnippet(d.Snippet.Code, d.Snippet.StartLine)
+		}
+		diags[i] = diag
+	}
+	return newValidateResult(result.Valid, diags)
+}
+
+// numberSnippet prefixes each line of code with its line number like the human readable output, e.g. `   3:   foo = "bar"`
+func numberSnippet(code string, startLine int) string {
+	lines := strings.Split(code, "\n")
+	for i, line := range lines {
+		lines[i] = fmt.Sprintf("%4d: %s", startLine+i, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func newValidateResult(valid bool, diags []*Diagnostic) ParseResult {
+	var errorCount, warningCount int
+	var warnings []string
+	for _, diag := range diags {
+		switch diag.Severity {
+		case DiagnosticSeverityError:
+			errorCount++
+		case DiagnosticSeverityWarning:
+			warningCount++
+			warnings = append(warnings, "Warning: "+diag.Summary)
+		}
+	}
+	result := "Success! The configuration is valid."
+	if !valid {
+		result = fmt.Sprintf("The configuration is invalid: %d error(s), %d warning(s).", errorCount, warningCount)
+	} else if warningCount > 0 {
+		result = fmt.Sprintf("Success! The configuration is valid, but there were %d warning(s).", warningCount)
+	}
+	return ParseResult{
+		Result:      result,
+		Warning:     strings.Join(warnings, "\n"),
+		HasError:    !valid,
+		Error:       nil,
+		Diagnostics: diags,
+	}
+}
@@ -0,0 +1,168 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+const validateJSONValidResult = `{
+  "format_version": "1.0",
+  "valid": true,
+  "error_count": 0,
+  "warning_count": 0,
+  "diagnostics": []
+}`
+
+const validateJSONInvalidResult = `{
+  "format_version": "1.0",
+  "valid": false,
+  "error_count": 1,
+  "warning_count": 1,
+  "diagnostics": [
+    {
+      "severity": "error",
+      "summary": "Unsupported argument",
+      "detail": "An argument named \"foo\" is not expected here.",
+      "range": {
+        "filename": "main.tf",
+        "start": {"line": 3, "column": 3, "byte": 40},
+        "end": {"line": 3, "column": 6, "byte": 43}
+      },
+      "snippet": {
+        "context": "resource \"null_resource\" \"foo\"",
+        "code": "  foo = \"bar\"",
+        "start_line": 3,
+        "highlight_start_offset": 2,
+        "highlight_end_offset": 5,
+        "values": []
+      }
+    },
+    {
+      "severity": "warning",
+      "summary": "Deprecated attribute",
+      "detail": ""
+    }
+  ]
+}`
+
+const validateTextInvalidResult = `
+╷
+│ Error: Unsupported argument
+│
+│   on main.tf line 3, in resource "null_resource" "foo":
+│    3:   foo = "bar"
+│
+│ An argument named "foo" is not expected here.
+╵
+`
+
+func TestValidateParserParse(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name   string
+		body   string
+		result ParseResult
+	}{
+		{
+			name: "valid json",
+			body: validateJSONValidResult,
+			result: ParseResult{
+				Result:      "Success! The configuration is valid.",
+				Diagnostics: []*Diagnostic{},
+			},
+		},
+		{
+			name: "invalid json",
+			body: validateJSONInvalidResult,
+			result: ParseResult{
+				Result:   "The configuration is invalid: 1 error(s), 1 warning(s).",
+				Warning:  "Warning: Deprecated attribute",
+				HasError: true,
+				Diagnostics: []*Diagnostic{
+					{
+						Severity: DiagnosticSeverityError,
+						Summary:  "Unsupported argument",
+						Detail:   `An argument named "foo" is not expected here.`,
+						File:     "main.tf",
+						Line:     3,
+						Snippet:  `   3:   foo = "bar"`,
+					},
+					{
+						Severity: DiagnosticSeverityWarning,
+						Summary:  "Deprecated attribute",
+					},
+				},
+			},
+		},
+		{
+			name: "multi-line snippet",
+			body: `{"format_version":"1.0","valid":false,"diagnostics":[{"severity":"error","summary":"Invalid expression","range":{"filename":"main.tf","start":{"line":4}},"snippet":{"code":"  tags = {\n    Name = \n  }","start_line":3}}]}`,
+			result: ParseResult{
+				Result:   "The configuration is invalid: 1 error(s), 0 warning(s).",
+				HasError: true,
+				Diagnostics: []*Diagnostic{
+					{
+						Severity: DiagnosticSeverityError,
+						Summary:  "Invalid expression",
+						File:     "main.tf",
+						Line:     4,
+						Snippet:  "   3:   tags = {\n   4:     Name = \n   5:   }",
+					},
+				},
+			},
+		},
+		{
+			name: "json other than validate",
+			body: `{"valid": true}`,
+			result: ParseResult{
+				HasParseError: true,
+			},
+		},
+		{
+			name: "valid text",
+			body: "Success! The configuration is valid.\n",
+			result: ParseResult{
+				Result: "Success! The configuration is valid.",
+			},
+		},
+		{
+			name: "invalid text",
+			body: validateTextInvalidResult,
+			result: ParseResult{
+				Result:   "The configuration is invalid: 1 error(s), 0 warning(s).",
+				HasError: true,
+				Diagnostics: []*Diagnostic{
+					{
+						Severity: DiagnosticSeverityError,
+						Summary:  "Unsupported argument",
+						Detail:   `An argument named "foo" is not expected here.`,
+						File:     "main.tf",
+						Line:     3,
+						Snippet:  `   3:   foo = "bar"`,
+					},
+				},
+			},
+		},
+		{
+			name: "parse error",
+			body: "Initializing the backend...\n",
+			result: ParseResult{
+				HasParseError: true,
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			result := NewValidateParser().Parse(testCase.body)
+			if diff := cmp.Diff(testCase.result, result, cmpopts.IgnoreFields(ParseResult{}, "Error")); diff != "" {
+				t.Error(diff)
+			}
+			if testCase.result.HasParseError && result.Error == nil {
+				t.Error("error should be returned")
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package terraform
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PolicyCheck is the result of a Sentinel policy evaluated in a remote run
+type PolicyCheck struct {
+	Name string
+	// EnforcementLevel is one of "advisory", "soft-mandatory", and "hard-mandatory"
+	EnforcementLevel string
+	Passed           bool
+}
+
+// CostEstimation is the cost estimation of a remote run.
+// Costs are kept as Terraform prints them, e.g. `$7.488` and `+$7.488`.
+type CostEstimation struct {
+	MatchedResourcesCount int
+	ResourcesCount        int
+	ProposedMonthlyCost   string
+	DeltaMonthlyCost      string
+}
+
+// RemoteRunParser parses the output of runs in HCP Terraform (Terraform Cloud) and Terraform Enterprise,
+// which are executed with the `cloud` block or the `remote` backend.
+type RemoteRunParser struct {
+	Header        *regexp.Regexp
+	RunURL        *regexp.Regexp
+	Escape        *regexp.Regexp
+	Policy        *regexp.Regexp
+	PolicyResult  *regexp.Regexp
+	CostResources *regexp.Regexp
+	Cost          *regexp.Regexp
+}
+
+// remoteRun is the information of a remote run
+type remoteRun struct {
+	RunURL         string
+	PolicyChecks   []*PolicyCheck
+	CostEstimation *CostEstimation
+}
+
+// NewRemoteRunParser is RemoteRunParser initialized with its Regexp
+func NewRemoteRunParser() *RemoteRunParser {
+	return &RemoteRunParser{
+		// e.g. `Running plan in HCP Terraform. Output will stream here. Pressing Ctrl-C`
+		Header: regexp.MustCompile(`(?m)^Running (?:plan|apply) in (?:Terraform Cloud|HCP Terraform|the remote backend)\.`),
+		// e.g. `https://app.terraform.io/app/my-org/my-workspace/runs/run-CZcmD7eagjhyX0vN`
+		RunURL: regexp.MustCompile(`^\s*(https?://\S+/runs/run-\w+)\s*$`),
+		// ANSI escape sequences are left in the logs streamed from the remote run
+		Escape: regexp.MustCompile(`\x1b\[[0-9;]*m`),
+		// e.g. `## Policy 1: my-policy-set/restrict-instance-type (soft-mandatory)`
+		Policy:       regexp.MustCompile(`^\s*## Policy \d+: (.+) \((advisory|soft-mandatory|hard-mandatory)\)$`),
+		PolicyResult: regexp.MustCompile(`^\s*Result: (true|false)$`),
+		// e.g. `Resources: 1 of 2 estimated` and `           $7.488/mo +$7.488`
+		CostResources: regexp.MustCompile(`^Resources: (\d+) of (\d+) estimated$`),
+		Cost:          regexp.MustCompile(`^\s+(\$\S+)/mo ([+-]\$\S+)$`),
+	}
+}
+
+// normalize removes ANSI escape sequences and carriage returns from the output of a remote run.
+// The output of local runs is returned as is.
+func (p *RemoteRunParser) normalize(body string) string {
+	if !p.Header.MatchString(p.Escape.ReplaceAllString(body, "")) {
+		return body
+	}
+	return strings.ReplaceAll(p.Escape.ReplaceAllString(body, ""), "\r", "")
+}
+
+// parse extracts the run URL, Sentinel policy results, and cost estimation from the output of a remote run
+func (p *RemoteRunParser) parse(body string, lines []string) remoteRun {
+	run := remoteRun{}
+	if !p.Header.MatchString(body) {
+		return run
+	}
+	var policy *PolicyCheck
+	for i, line := range lines {
+		if run.RunURL == "" && strings.HasPrefix(line, "To view this run in a browser, visit:") && i+1 < len(lines) {
+			if m := p.RunURL.FindStringSubmatch(lines[i+1]); m != nil {
+				run.RunURL = m[1]
+			}
+			continue
+		}
+		if m := p.Policy.FindStringSubmatch(line); m != nil {
+			policy = &PolicyCheck{
+				Name:             m[1],
+				EnforcementLevel: m[2],
+			}
+			run.PolicyChecks = append(run.PolicyChecks, policy)
+			continue
+		}
+		if policy != nil {
+			// Only the first result after the header is the result of the policy.
+			// The following ones are the results of rules.
+			if m := p.PolicyResult.FindStringSubmatch(line); m != nil {
+				policy.Passed = m[1] == "true"
+				policy = nil
+			}
+			continue
+		}
+		if m := p.CostResources.FindStringSubmatch(line); m != nil && i+1 < len(lines) {
+			c := p.Cost.FindStringSubmatch(lines[i+1])
+			if c == nil {
+				continue
+			}
+			matched, _ := strconv.Atoi(m[1])
+			total, _ := strconv.Atoi(m[2])
+			run.CostEstimation = &CostEstimation{
+				MatchedResourcesCount: matched,
+				ResourcesCount:        total,
+				ProposedMonthlyCost:   c[1],
+				DeltaMonthlyCost:      c[2],
+			}
+		}
+	}
+	return run
+}
@@ -0,0 +1,152 @@
+package terraform
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const remotePlanResult = `Running plan in HCP Terraform. Output will stream here. Pressing Ctrl-C
+will stop streaming the logs, but will not stop the plan running remotely.
+
+Preparing the remote plan...
+
+To view this run in a browser, visit:
+https://app.terraform.io/app/my-org/my-workspace/runs/run-CZcmD7eagjhyX0vN
+
+Waiting for the plan to start...
+
+Terraform v1.9.5
+on linux_amd64
+Initializing plugins and modules...
+null_resource.foo: Refreshing state... [id=123]
+
+Terraform used the selected providers to generate the following execution
+plan. Resource actions are indicated with the following symbols:
+  + create
+
+Terraform will perform the following actions:
+
+  # null_resource.bar will be created
+  + resource "null_resource" "bar" {
+      + id = (known after apply)
+    }
+
+` + "\x1b[1mPlan:\x1b[0m 1 to add, 0 to change, 0 to destroy.\r" + `
+
+------------------------------------------------------------------------
+
+Cost Estimation:
+
+Resources: 1 of 2 estimated
+           $7.488/mo +$7.488
+
+------------------------------------------------------------------------
+
+Organization policy check:
+
+Sentinel Result: false
+
+Sentinel evaluated to false because one or more Sentinel policies evaluated
+to false. This false was not due to an undefined value or runtime error.
+
+2 policies evaluated.
+
+## Policy 1: my-policy-set/restrict-instance-type (hard-mandatory)
+
+Result: true
+
+TRUE - restrict-instance-type.sentinel:12:1 - Rule "main"
+
+## Policy 2: my-policy-set/require-tags (advisory)
+
+Result: false
+
+FALSE - require-tags.sentinel:8:1 - Rule "main"
+  Result: false
+`
+
+const remoteApplyResult = `Running apply in the remote backend. Output will stream here. Pressing Ctrl-C
+will cancel the remote apply if it's still pending. If the apply started it
+will stop streaming the logs, but will not stop the apply running remotely.
+
+Preparing the remote apply...
+
+To view this run in a browser, visit:
+https://tfe.example.com/app/my-org/my-workspace/runs/run-abc123
+
+Waiting for the plan to start...
+
+null_resource.bar: Creating...
+null_resource.bar: Creation complete after 0s [id=456]
+
+Apply complete! Resources: 1 added, 0 changed, 0 destroyed.
+`
+
+func TestPlanParserParseRemoteRun(t *testing.T) {
+	t.Parallel()
+	result := NewPlanParser().Parse(remotePlanResult)
+	if result.HasParseError {
+		t.Fatal(result.Error)
+	}
+	if result.Result != "Plan: 1 to add, 0 to change, 0 to destroy." {
+		t.Errorf("Result: got %q", result.Result)
+	}
+	if diff := cmp.Diff([]string{"null_resource.bar"}, result.CreatedResources); diff != "" {
+		t.Error(diff)
+	}
+	if result.RunURL != "https://app.terraform.io/app/my-org/my-workspace/runs/run-CZcmD7eagjhyX0vN" {
+		t.Errorf("RunURL: got %q", result.RunURL)
+	}
+	if strings.Contains(result.ChangedResult, "Cost Estimation") {
+		t.Errorf("ChangedResult must not contain the cost estimation: %s", result.ChangedResult)
+	}
+	if diff := cmp.Diff(&CostEstimation{
+		MatchedResourcesCount: 1,
+		ResourcesCount:        2,
+		ProposedMonthlyCost:   "$7.488",
+		DeltaMonthlyCost:      "+$7.488",
+	}, result.CostEstimation); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff([]*PolicyCheck{
+		{
+			Name:             "my-policy-set/restrict-instance-type",
+			EnforcementLevel: "hard-mandatory",
+			Passed:           true,
+		},
+		{
+			Name:             "my-policy-set/require-tags",
+			EnforcementLevel: "advisory",
+			Passed:           false,
+		},
+	}, result.PolicyChecks); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestApplyParserParseRemoteRun(t *testing.T) {
+	t.Parallel()
+	result := NewApplyParser().Parse(remoteApplyResult)
+	if result.HasParseError {
+		t.Fatal(result.Error)
+	}
+	if result.RunURL != "https://tfe.example.com/app/my-org/my-workspace/runs/run-abc123" {
+		t.Errorf("RunURL: got %q", result.RunURL)
+	}
+	if result.CostEstimation != nil {
+		t.Errorf("CostEstimation: wanted nil, got %+v", result.CostEstimation)
+	}
+	if len(result.AppliedResources) != 1 || result.AppliedResources[0].Status != AppliedResourceStatusSucceeded {
+		t.Errorf("AppliedResources: got %+v", result.AppliedResources)
+	}
+}
+
+func TestPlanParserParseLocalRun(t *testing.T) {
+	t.Parallel()
+	result := NewPlanParser().Parse("To view this run in a browser, visit:\nhttps://app.terraform.io/app/my-org/my-workspace/runs/run-abc\n\nPlan: 1 to add, 0 to change, 0 to destroy.\n")
+	if result.RunURL != "" {
+		t.Errorf("RunURL must be empty if the run isn't remote: got %q", result.RunURL)
+	}
+}
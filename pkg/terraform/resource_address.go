@@ -0,0 +1,167 @@
+package terraform
+
+import (
+	"strings"
+)
+
+// ResourceAddress is a parsed resource address like `module.vpc.aws_subnet.private["a"]`
+type ResourceAddress struct {
+	// Address is the original address
+	Address string
+	// ModulePath is the module part of the address, e.g. `module.vpc` and `module.a["x"].module.b`.
+	// It is empty for resources in the root module.
+	ModulePath string
+	// Mode is "managed" for resources and "data" for data sources
+	Mode string
+	Type string
+	Name string
+	// Index is the instance key without brackets, e.g. `0` and `"a"`. It is empty if the resource has no count or for_each.
+	Index string
+	// Provider is the provider of the resource.
+	// It is guessed from the prefix of the resource type, e.g. `aws` of `aws_instance`,
+	// unless the provider is known from the JSON plan.
+	Provider string
+}
+
+// ResourceGroup is a group of resources returned by the template functions groupByModule, groupByType, and groupByProvider
+type ResourceGroup struct {
+	Key       string
+	Resources []*ResourceAddress
+}
+
+// ParseResourceAddress parses a resource address.
+// If the address is malformed, only Address is set.
+func ParseResourceAddress(address string) *ResourceAddress {
+	addr := &ResourceAddress{
+		Address: address,
+	}
+	steps := splitAddress(address)
+	var modules []string
+	for len(steps) > 1 && steps[0] == "module" {
+		modules = append(modules, "module."+steps[1])
+		steps = steps[2:]
+	}
+	mode := "managed"
+	if len(steps) > 0 && steps[0] == "data" {
+		mode = "data"
+		steps = steps[1:]
+	}
+	if len(steps) != 2 { //nolint:mnd
+		return addr
+	}
+	addr.ModulePath = strings.Join(modules, ".")
+	addr.Mode = mode
+	addr.Type = steps[0]
+	addr.Name, addr.Index = splitIndex(steps[1])
+	addr.Provider, _, _ = strings.Cut(addr.Type, "_")
+	return addr
+}
+
+// parseResourceAddresses parses addresses. nil is returned if addresses is empty.
+func parseResourceAddresses(addresses []string) []*ResourceAddress {
+	if len(addresses) == 0 {
+		return nil
+	}
+	ret := make([]*ResourceAddress, len(addresses))
+	for i, address := range addresses {
+		ret[i] = ParseResourceAddress(address)
+	}
+	return ret
+}
+
+// splitAddress splits an address by dots outside of instance keys, because keys can contain dots like `["a.b"]`.
+func splitAddress(address string) []string {
+	var steps []string
+	depth := 0
+	quoted := false
+	start := 0
+	for i := 0; i < len(address); i++ {
+		switch c := address[i]; {
+		case quoted:
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				quoted = false
+			}
+		case c == '"':
+			quoted = true
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		case c == '.' && depth == 0:
+			steps = append(steps, address[start:i])
+			start = i + 1
+		}
+	}
+	return append(steps, address[start:])
+}
+
+// splitIndex splits `private["a"]` into `private` and `"a"`
+func splitIndex(step string) (string, string) {
+	name, index, ok := strings.Cut(step, "[")
+	if !ok || !strings.HasSuffix(index, "]") {
+		return step, ""
+	}
+	return name, strings.TrimSuffix(index, "]")
+}
+
+// withResourceAddresses sets the parsed addresses of created, updated, deleted, replaced, and imported resources
+func withResourceAddresses(result ParseResult) ParseResult {
+	result.CreatedAddresses = parseResourceAddresses(result.CreatedResources)
+	result.UpdatedAddresses = parseResourceAddresses(result.UpdatedResources)
+	result.DeletedAddresses = parseResourceAddresses(result.DeletedResources)
+	result.ReplacedAddresses = parseResourceAddresses(result.ReplacedResources)
+	result.ImportedAddresses = parseResourceAddresses(result.ImportedResources)
+	for _, mr := range result.ModuleResults {
+		mr.CreatedAddresses = parseResourceAddresses(mr.CreatedResources)
+		mr.UpdatedAddresses = parseResourceAddresses(mr.UpdatedResources)
+		mr.DeletedAddresses = parseResourceAddresses(mr.DeletedResources)
+		mr.ReplacedAddresses = parseResourceAddresses(mr.ReplacedResources)
+		mr.ImportedAddresses = parseResourceAddresses(mr.ImportedResources)
+	}
+	return result
+}
+
+// groupResources groups resources by key, in order of first appearance
+func groupResources(resources []*ResourceAddress, key func(*ResourceAddress) string) []*ResourceGroup {
+	var groups []*ResourceGroup
+	index := map[string]int{}
+	for _, rsc := range resources {
+		k := key(rsc)
+		if i, ok := index[k]; ok {
+			groups[i].Resources = append(groups[i].Resources, rsc)
+			continue
+		}
+		index[k] = len(groups)
+		groups = append(groups, &ResourceGroup{
+			Key:       k,
+			Resources: []*ResourceAddress{rsc},
+		})
+	}
+	return groups
+}
+
+// groupByModule groups resources by ModulePath. The key of the root module is empty.
+func groupByModule(resources []*ResourceAddress) []*ResourceGroup {
+	return groupResources(resources, func(rsc *ResourceAddress) string {
+		return rsc.ModulePath
+	})
+}
+
+// groupByType groups resources by Type. Data sources are prefixed with `data.`.
+func groupByType(resources []*ResourceAddress) []*ResourceGroup {
+	return groupResources(resources, func(rsc *ResourceAddress) string {
+		if rsc.Mode == "data" {
+			return "data." + rsc.Type
+		}
+		return rsc.Type
+	})
+}
+
+// groupByProvider groups resources by Provider
+func groupByProvider(resources []*ResourceAddress) []*ResourceGroup {
+	return groupResources(resources, func(rsc *ResourceAddress) string {
+		return rsc.Provider
+	})
+}
@@ -0,0 +1,120 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseResourceAddress(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name    string
+		address string
+		exp     *ResourceAddress
+	}{
+		{
+			name:    "root module",
+			address: "aws_instance.web",
+			exp: &ResourceAddress{
+				Address:  "aws_instance.web",
+				Mode:     "managed",
+				Type:     "aws_instance",
+				Name:     "web",
+				Provider: "aws",
+			},
+		},
+		{
+			name:    "module with for_each",
+			address: `module.vpc.aws_subnet.private["a.b"]`,
+			exp: &ResourceAddress{
+				Address:    `module.vpc.aws_subnet.private["a.b"]`,
+				ModulePath: "module.vpc",
+				Mode:       "managed",
+				Type:       "aws_subnet",
+				Name:       "private",
+				Index:      `"a.b"`,
+				Provider:   "aws",
+			},
+		},
+		{
+			name:    "nested modules and data source",
+			address: `module.a["x"].module.b[0].data.google_project.this[1]`,
+			exp: &ResourceAddress{
+				Address:    `module.a["x"].module.b[0].data.google_project.this[1]`,
+				ModulePath: `module.a["x"].module.b[0]`,
+				Mode:       "data",
+				Type:       "google_project",
+				Name:       "this",
+				Index:      "1",
+				Provider:   "google",
+			},
+		},
+		{
+			name:    "malformed",
+			address: "foo",
+			exp: &ResourceAddress{
+				Address: "foo",
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(testCase.exp, ParseResourceAddress(testCase.address)); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestGroupByType(t *testing.T) {
+	t.Parallel()
+	resources := parseResourceAddresses([]string{
+		"aws_iam_role.a",
+		"module.vpc.aws_subnet.a",
+		"aws_iam_role.b",
+		"data.aws_iam_role.c",
+	})
+	groups := groupByType(resources)
+	keys := make([]string, len(groups))
+	counts := make([]int, len(groups))
+	for i, group := range groups {
+		keys[i] = group.Key
+		counts[i] = len(group.Resources)
+	}
+	if diff := cmp.Diff([]string{"aws_iam_role", "aws_subnet", "data.aws_iam_role"}, keys); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff([]int{2, 1, 1}, counts); diff != "" {
+		t.Error(diff)
+	}
+	if modules := groupByModule(resources); len(modules) != 2 || modules[0].Key != "" || modules[1].Key != "module.vpc" {
+		t.Errorf("groupByModule: got %+v", modules)
+	}
+	if providers := groupByProvider(resources); len(providers) != 1 || providers[0].Key != "aws" {
+		t.Errorf("groupByProvider: got %+v", providers)
+	}
+}
+
+func TestResourceTypeSummaryTemplate(t *testing.T) {
+	t.Parallel()
+	template := NewPlanTemplate(`{{template "resource_type_summary" .}}`)
+	template.SetValue(CommonTemplate{
+		CreatedAddresses: parseResourceAddresses([]string{"aws_iam_role.a", "aws_iam_role.b", "aws_s3_bucket.c"}),
+		DeletedAddresses: parseResourceAddresses([]string{"aws_iam_role.d"}),
+	})
+	body, err := template.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := `
+* Create
+  * aws_iam_role ×2
+  * aws_s3_bucket ×1
+* Delete
+  * aws_iam_role ×1`
+	if diff := cmp.Diff(exp, body); diff != "" {
+		t.Error(diff)
+	}
+}
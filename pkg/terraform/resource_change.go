@@ -0,0 +1,148 @@
+package terraform
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ResourceChange is the attribute-level change of a resource in a plan.
+// Action is one of "create", "update", "delete", and "replace".
+type ResourceChange struct {
+	Address           string
+	Action            string
+	ChangedAttributes []*AttributeChange
+	// ForcesReplacement is a list of attributes which force the replacement of the resource
+	ForcesReplacement []string
+}
+
+// AttributeChange is a change of a top-level attribute or block of a resource.
+// Before and After are rendered in the same format as terraform plan (e.g. `"t3.small"`).
+// They are empty if the value doesn't exist (e.g. Before of a created attribute or a nested block).
+type AttributeChange struct {
+	Name   string
+	Before string
+	After  string
+}
+
+const (
+	forcesReplacementSuffix = " # forces replacement"
+	knownAfterApply         = "(known after apply)"
+	sensitiveValue          = "(sensitive value)"
+)
+
+var (
+	// attributeChangeRe matches a changed attribute or block in the resource body.
+	// e.g. `~ instance_type = "t3.small" -> "t3.large"`, `- pages {`
+	attributeChangeRe = regexp.MustCompile(`^(?:[~+-]|-/\+|\+/-) (.+)$`)
+	heredocStartRe    = regexp.MustCompile(`<<-?([A-Za-z_]+)$`)
+)
+
+// resourceChangeCollector collects ResourceChange from lines of terraform plan.
+// Call begin when a resource header like `# aws_instance.web will be created` is found,
+// and add for every line.
+type resourceChangeCollector struct {
+	changes []*ResourceChange
+	current *ResourceChange
+	// depth is the nesting depth of braces in the resource body.
+	// It's 0 until the resource body starts.
+	depth   int
+	started bool
+	heredoc string
+}
+
+func (c *resourceChangeCollector) begin(address, action string) {
+	c.current = &ResourceChange{
+		Address: address,
+		Action:  action,
+	}
+	c.changes = append(c.changes, c.current)
+	c.depth = 0
+	c.started = false
+	c.heredoc = ""
+}
+
+func (c *resourceChangeCollector) add(line string) { //nolint:cyclop
+	if c.current == nil {
+		return
+	}
+	trimmed := strings.TrimSpace(line)
+	if c.heredoc != "" {
+		if trimmed == c.heredoc || strings.HasPrefix(trimmed, c.heredoc+" ") {
+			c.heredoc = ""
+		}
+		return
+	}
+	if !c.started {
+		// Skip comments like `# (moved from ...)` between the header and the body
+		if strings.HasSuffix(trimmed, "{") {
+			c.started = true
+			c.depth = 1
+		}
+		return
+	}
+	if strings.HasPrefix(trimmed, "}") || strings.HasPrefix(trimmed, "]") || strings.HasPrefix(trimmed, ")") {
+		c.depth--
+		if c.depth == 0 {
+			c.current = nil
+		}
+		return
+	}
+	forcesReplacement := strings.HasSuffix(trimmed, forcesReplacementSuffix)
+	trimmed = strings.TrimSuffix(trimmed, forcesReplacementSuffix)
+	opens := strings.HasSuffix(trimmed, "{") || strings.HasSuffix(trimmed, "[") || strings.HasSuffix(trimmed, "(")
+	var heredoc string
+	if m := heredocStartRe.FindStringSubmatch(trimmed); m != nil {
+		heredoc = m[1]
+	}
+	if c.depth == 1 {
+		if m := attributeChangeRe.FindStringSubmatch(trimmed); m != nil {
+			attr := newAttributeChange(trimmed[:len(trimmed)-len(m[1])-1], m[1], opens || heredoc != "")
+			c.current.ChangedAttributes = append(c.current.ChangedAttributes, attr)
+			if forcesReplacement {
+				c.current.ForcesReplacement = append(c.current.ForcesReplacement, attr.Name)
+			}
+		}
+	}
+	if heredoc != "" {
+		c.heredoc = heredoc
+		return
+	}
+	if opens {
+		c.depth++
+	}
+}
+
+// newAttributeChange parses a changed attribute like `instance_type = "t3.small" -> "t3.large"`.
+// If multiline is true, the value continues to the following lines, so it's omitted.
+func newAttributeChange(symbol, s string, multiline bool) *AttributeChange {
+	name, value, ok := strings.Cut(s, " = ")
+	if !ok {
+		// nested block like `pages {`
+		return &AttributeChange{
+			Name: strings.TrimSpace(strings.TrimSuffix(s, "{")),
+		}
+	}
+	attr := &AttributeChange{
+		Name: strings.TrimSpace(name),
+	}
+	value = strings.TrimSpace(value)
+	if multiline {
+		return attr
+	}
+	switch symbol {
+	case "+":
+		attr.After = value
+	case "-":
+		attr.Before = strings.TrimSuffix(value, " -> null")
+		attr.After = "null"
+	default:
+		before, after, ok := strings.Cut(value, " -> ")
+		if ok {
+			attr.Before = before
+			attr.After = after
+		} else {
+			attr.After = value
+		}
+	}
+	return attr
+}
@@ -0,0 +1,83 @@
+package terraform
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestResourceChangeCollector(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name string
+		body string
+		exp  []*AttributeChange
+	}{
+		{
+			name: "multiline values",
+			body: `  ~ resource "aws_iam_policy" "ci" {
+      ~ policy = jsonencode(
+          ~ {
+              ~ Statement = [
+                  - {
+                      - Effect = "Allow"
+                    },
+                ]
+            }
+        )
+      + tags   = {
+          + "Team" = "sre"
+        }
+        # (2 unchanged attributes hidden)
+    }`,
+			exp: []*AttributeChange{
+				{Name: "policy"},
+				{Name: "tags"},
+			},
+		},
+		{
+			name: "heredoc",
+			body: `  ~ resource "local_file" "foo" {
+      ~ content = <<-EOT
+          - {
+          + }
+        EOT
+      - filename = "foo.txt" -> null
+    }`,
+			exp: []*AttributeChange{
+				{Name: "content"},
+				{Name: "filename", Before: `"foo.txt"`, After: "null"},
+			},
+		},
+		{
+			name: "sensitive and unknown values",
+			body: `  ~ resource "aws_db_instance" "main" {
+      ~ password = (sensitive value)
+      ~ arn      = "arn:aws:rds:foo" -> (known after apply)
+      + port     = 5432
+    }`,
+			exp: []*AttributeChange{
+				{Name: "password", After: "(sensitive value)"},
+				{Name: "arn", Before: `"arn:aws:rds:foo"`, After: "(known after apply)"},
+				{Name: "port", After: "5432"},
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			c := &resourceChangeCollector{}
+			c.begin("foo", "update")
+			for line := range strings.SplitSeq(testCase.body, "\n") {
+				c.add(line)
+			}
+			if c.current != nil {
+				t.Error("the resource body must be closed")
+			}
+			if diff := cmp.Diff(testCase.exp, c.changes[0].ChangedAttributes); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package terraform
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Risk levels of a plan, from the lowest to the highest
+const (
+	RiskLevelLow      = "low"
+	RiskLevelMedium   = "medium"
+	RiskLevelHigh     = "high"
+	RiskLevelCritical = "critical"
+)
+
+// RiskLevels is the list of risk levels from the lowest to the highest
+var RiskLevels = []string{RiskLevelLow, RiskLevelMedium, RiskLevelHigh, RiskLevelCritical}
+
+// CompareRiskLevel returns a negative number if a is lower than b, zero if they are same, and a positive number otherwise.
+// Unknown levels are lower than low.
+func CompareRiskLevel(a, b string) int {
+	return slices.Index(RiskLevels, a) - slices.Index(RiskLevels, b)
+}
+
+// ValidateRiskLevel returns an error if level isn't a known risk level
+func ValidateRiskLevel(level string) error {
+	if !slices.Contains(RiskLevels, level) {
+		return fmt.Errorf("risk level must be one of %v: %q", RiskLevels, level)
+	}
+	return nil
+}
+
+// RiskRule is a rule to evaluate the risk of a plan.
+// A rule matches the resources which match all of ResourceTypes, Actions, and ModulePaths.
+// Empty conditions match any resources.
+type RiskRule struct {
+	Name  string
+	Level string
+	// ResourceTypes is glob patterns of resource types, e.g. `aws_iam_*`
+	ResourceTypes []string
+	// Actions is the actions of resources: create, update, delete, replace, import, move, and forget
+	Actions []string
+	// ModulePaths is glob patterns of module paths like `module.vpc` or Terragrunt modules like `prod/vpc`
+	ModulePaths []string
+	// MinCount is the number of matched resources required to apply the rule. 1 if it is zero
+	MinCount int
+	// Reason is the message shown to reviewers. It defaults to the name of the rule
+	Reason string
+}
+
+// Risk is the risk of a plan evaluated by RiskEngine
+type Risk struct {
+	// Level is the highest level of the matched rules. It is low if no rule matches
+	Level   string
+	Reasons []*RiskReason
+}
+
+// RiskReason is a rule matching a plan
+type RiskReason struct {
+	Rule      string
+	Level     string
+	Reason    string
+	Resources []string
+}
+
+// DefaultRiskRules flags changes which are hard to revert, e.g. replacements of IAM, KMS, and databases
+var DefaultRiskRules = []*RiskRule{
+	{
+		Name:          "iam-change",
+		Level:         RiskLevelMedium,
+		ResourceTypes: []string{"aws_iam_*", "google_*_iam_*", "google_service_account*", "azurerm_role_*"},
+		Actions:       []string{"create", "update", "import"},
+		Reason:        "IAM resources are changed",
+	},
+	{
+		Name:          "iam-destroy",
+		Level:         RiskLevelHigh,
+		ResourceTypes: []string{"aws_iam_*", "google_*_iam_*", "google_service_account*", "azurerm_role_*"},
+		Actions:       []string{"delete", "replace"},
+		Reason:        "IAM resources are destroyed or replaced",
+	},
+	{
+		Name:          "kms-destroy",
+		Level:         RiskLevelCritical,
+		ResourceTypes: []string{"aws_kms_*", "google_kms_*", "azurerm_key_vault*"},
+		Actions:       []string{"delete", "replace"},
+		Reason:        "KMS keys are destroyed or replaced. Data encrypted with them can no longer be decrypted",
+	},
+	{
+		Name:  "database-destroy",
+		Level: RiskLevelCritical,
+		ResourceTypes: []string{
+			"aws_db_instance", "aws_rds_cluster", "aws_rds_cluster_instance", "aws_dynamodb_table", "aws_elasticache_*", "aws_docdb_cluster",
+			"google_sql_database_instance", "google_sql_database", "google_spanner_*", "google_bigtable_instance", "google_bigquery_dataset",
+			"azurerm_*sql_*", "azurerm_cosmosdb_*",
+		},
+		Actions: []string{"delete", "replace"},
+		Reason:  "Databases are destroyed or replaced. Data may be lost",
+	},
+}
+
+// RiskEngine evaluates the risk of a plan with rules
+type RiskEngine struct {
+	Rules []*RiskRule
+}
+
+// NewRiskEngine returns RiskEngine with rules. If withDefaultRules is true, DefaultRiskRules are evaluated as well
+func NewRiskEngine(rules []*RiskRule, withDefaultRules bool) *RiskEngine {
+	if withDefaultRules {
+		rules = append(slices.Clone(DefaultRiskRules), rules...)
+	}
+	return &RiskEngine{
+		Rules: rules,
+	}
+}
+
+// riskTarget is a changed resource evaluated by rules
+type riskTarget struct {
+	address *ResourceAddress
+	action  string
+	// module is the Terragrunt module of the resource. It is empty unless the result is consolidated
+	module string
+}
+
+// Evaluate returns the risk of result
+func (e *RiskEngine) Evaluate(result ParseResult) *Risk {
+	targets := riskTargets(result)
+	risk := &Risk{
+		Level: RiskLevelLow,
+	}
+	for _, rule := range e.Rules {
+		var resources []string
+		for _, target := range targets {
+			if rule.match(target) {
+				resources = append(resources, target.address.Address)
+			}
+		}
+		if len(resources) == 0 || len(resources) < rule.MinCount {
+			continue
+		}
+		reason := rule.Reason
+		if reason == "" {
+			reason = rule.Name
+		}
+		risk.Reasons = append(risk.Reasons, &RiskReason{
+			Rule:      rule.Name,
+			Level:     rule.Level,
+			Reason:    reason,
+			Resources: resources,
+		})
+		if CompareRiskLevel(rule.Level, risk.Level) > 0 {
+			risk.Level = rule.Level
+		}
+	}
+	// Reasons with the highest level come first
+	slices.SortStableFunc(risk.Reasons, func(a, b *RiskReason) int {
+		return CompareRiskLevel(b.Level, a.Level)
+	})
+	return risk
+}
+
+func (r *RiskRule) match(target *riskTarget) bool {
+	if len(r.Actions) != 0 && !slices.Contains(r.Actions, target.action) {
+		return false
+	}
+	if len(r.ResourceTypes) != 0 && !matchGlobs(r.ResourceTypes, target.address.Type) {
+		return false
+	}
+	if len(r.ModulePaths) != 0 && !matchGlobs(r.ModulePaths, target.address.ModulePath) && (target.module == "" || !matchGlobs(r.ModulePaths, target.module)) {
+		return false
+	}
+	return true
+}
+
+// matchGlobs returns true if s matches any of patterns
+func matchGlobs(patterns []string, s string) bool {
+	for _, pattern := range patterns {
+		if MatchAddressPattern(pattern, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchAddressPattern returns true if s matches pattern.
+// `*` matches any string including `.`, `/`, and `"`, and the other characters match themselves.
+// Unlike path.Match, `[` and `]` aren't special, so instances like `module.db["prod"].*` are written as they are.
+func MatchAddressPattern(pattern, s string) bool {
+	// p and i are the positions in pattern and s. star and next are the positions to backtrack to when a character doesn't match
+	p, i, star, next := 0, 0, -1, 0
+	for i < len(s) {
+		switch {
+		case p < len(pattern) && pattern[p] == '*':
+			star, next = p, i
+			p++
+		case p < len(pattern) && pattern[p] == s[i]:
+			p++
+			i++
+		case star != -1:
+			next++
+			p, i = star+1, next
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+// ValidateAddressPatterns returns an error if any of patterns is empty, which matches nothing
+func ValidateAddressPatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if strings.TrimSpace(pattern) == "" {
+			return errors.New("a pattern must not be empty")
+		}
+	}
+	return nil
+}
+
+// riskTargets returns the changed resources of result.
+// If result is consolidated per Terragrunt module, the module of each resource is set as well.
+func riskTargets(result ParseResult) []*riskTarget {
+	if len(result.ModuleResults) == 0 {
+		return appendRiskTargets(nil, "", result.CreatedAddresses, result.UpdatedAddresses, result.DeletedAddresses, result.ReplacedAddresses,
+			result.ImportedAddresses, result.MovedResources, result.ForgottenResources)
+	}
+	var targets []*riskTarget
+	for _, mr := range result.ModuleResults {
+		targets = appendRiskTargets(targets, mr.Module, mr.CreatedAddresses, mr.UpdatedAddresses, mr.DeletedAddresses, mr.ReplacedAddresses,
+			mr.ImportedAddresses, mr.MovedResources, mr.ForgottenResources)
+	}
+	return targets
+}
+
+func appendRiskTargets(targets []*riskTarget, module string, created, updated, deleted, replaced, imported []*ResourceAddress, moved []*MovedResource, forgotten []string) []*riskTarget {
+	for _, changes := range []struct {
+		action string
+		addrs  []*ResourceAddress
+	}{
+		{"create", created},
+		{"update", updated},
+		{"delete", deleted},
+		{"replace", replaced},
+		{"import", imported},
+		{"forget", parseResourceAddresses(forgotten)},
+	} {
+		for _, addr := range changes.addrs {
+			targets = append(targets, &riskTarget{address: addr, action: changes.action, module: module})
+		}
+	}
+	for _, mv := range moved {
+		targets = append(targets, &riskTarget{address: ParseResourceAddress(mv.After), action: "move", module: module})
+	}
+	return targets
+}
+
+// RiskParser evaluates the risk of the result of Parser with Engine
+type RiskParser struct {
+	Parser Parser
+	Engine *RiskEngine
+}
+
+// Parse parses body with Parser and sets the risk to ParseResult.Risk
+func (p *RiskParser) Parse(body string) ParseResult {
+	result := p.Parser.Parse(body)
+	if !result.HasParseError {
+		result.Risk = p.Engine.Evaluate(result)
+	}
+	return result
+}
+
+// ProtectedResourceParser finds the protected resources which are deleted or replaced in the result of Parser
+type ProtectedResourceParser struct {
+	Parser Parser
+	// Patterns is glob patterns of protected resource addresses like `module.prod_db.*`
+	Patterns []string
+}
+
+// Parse parses body with Parser and sets the deleted or replaced protected resources to ParseResult.ProtectedResources
+func (p *ProtectedResourceParser) Parse(body string) ParseResult {
+	result := p.Parser.Parse(body)
+	if !result.HasParseError {
+		result.ProtectedResources = FindProtectedResources(result, p.Patterns)
+	}
+	return result
+}
+
+// FindProtectedResources returns the addresses of the deleted or replaced resources matching patterns
+func FindProtectedResources(result ParseResult, patterns []string) []string {
+	var protected []string
+	for _, addresses := range [][]string{result.DeletedResources, result.ReplacedResources} {
+		for _, address := range addresses {
+			if matchGlobs(patterns, address) {
+				protected = append(protected, address)
+			}
+		}
+	}
+	return protected
+}
@@ -0,0 +1,216 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRiskEngineEvaluate(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name   string
+		rules  []*RiskRule
+		result ParseResult
+		exp    *Risk
+	}{
+		{
+			name: "no changes",
+			exp: &Risk{
+				Level: RiskLevelLow,
+			},
+		},
+		{
+			name: "default rules",
+			result: ParseResult{
+				CreatedAddresses:  parseResourceAddresses([]string{"aws_iam_role.foo", "aws_instance.web"}),
+				ReplacedAddresses: parseResourceAddresses([]string{"module.db.aws_db_instance.main", "aws_kms_key.main"}),
+			},
+			exp: &Risk{
+				Level: RiskLevelCritical,
+				Reasons: []*RiskReason{
+					{
+						Rule:      "kms-destroy",
+						Level:     RiskLevelCritical,
+						Reason:    "KMS keys are destroyed or replaced. Data encrypted with them can no longer be decrypted",
+						Resources: []string{"aws_kms_key.main"},
+					},
+					{
+						Rule:      "database-destroy",
+						Level:     RiskLevelCritical,
+						Reason:    "Databases are destroyed or replaced. Data may be lost",
+						Resources: []string{"module.db.aws_db_instance.main"},
+					},
+					{
+						Rule:      "iam-change",
+						Level:     RiskLevelMedium,
+						Reason:    "IAM resources are changed",
+						Resources: []string{"aws_iam_role.foo"},
+					},
+				},
+			},
+		},
+		{
+			name: "count and module paths",
+			rules: []*RiskRule{
+				{
+					Name:     "many-deletions",
+					Level:    RiskLevelHigh,
+					Actions:  []string{"delete"},
+					MinCount: 2,
+				},
+				{
+					Name:        "prod",
+					Level:       RiskLevelMedium,
+					ModulePaths: []string{"prod/*"},
+				},
+				{
+					Name:        "network",
+					Level:       RiskLevelMedium,
+					ModulePaths: []string{"module.vpc"},
+					Reason:      "the network is changed",
+				},
+			},
+			result: ParseResult{
+				ModuleResults: []*ModuleResult{
+					{
+						Module:           "prod/app",
+						DeletedAddresses: parseResourceAddresses([]string{"aws_instance.web"}),
+					},
+					{
+						Module:           "dev/network",
+						DeletedAddresses: parseResourceAddresses([]string{"module.vpc.aws_subnet.a"}),
+					},
+				},
+			},
+			exp: &Risk{
+				Level: RiskLevelHigh,
+				Reasons: []*RiskReason{
+					{
+						Rule:      "many-deletions",
+						Level:     RiskLevelHigh,
+						Reason:    "many-deletions",
+						Resources: []string{"aws_instance.web", "module.vpc.aws_subnet.a"},
+					},
+					{
+						Rule:      "prod",
+						Level:     RiskLevelMedium,
+						Reason:    "prod",
+						Resources: []string{"aws_instance.web"},
+					},
+					{
+						Rule:      "network",
+						Level:     RiskLevelMedium,
+						Reason:    "the network is changed",
+						Resources: []string{"module.vpc.aws_subnet.a"},
+					},
+				},
+			},
+		},
+		{
+			name: "min count isn't satisfied",
+			rules: []*RiskRule{
+				{
+					Name:     "many-deletions",
+					Level:    RiskLevelHigh,
+					Actions:  []string{"delete"},
+					MinCount: 2,
+				},
+			},
+			result: ParseResult{
+				DeletedAddresses: parseResourceAddresses([]string{"aws_instance.web"}),
+			},
+			exp: &Risk{
+				Level: RiskLevelLow,
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			engine := NewRiskEngine(testCase.rules, testCase.rules == nil)
+			if diff := cmp.Diff(engine.Evaluate(testCase.result), testCase.exp); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestCompareRiskLevel(t *testing.T) {
+	t.Parallel()
+	if CompareRiskLevel(RiskLevelCritical, RiskLevelHigh) <= 0 {
+		t.Error("critical must be higher than high")
+	}
+	if CompareRiskLevel(RiskLevelLow, RiskLevelMedium) >= 0 {
+		t.Error("low must be lower than medium")
+	}
+	if CompareRiskLevel(RiskLevelHigh, RiskLevelHigh) != 0 {
+		t.Error("the same levels must be equal")
+	}
+	if ValidateRiskLevel("severe") == nil {
+		t.Error("unknown levels must be invalid")
+	}
+}
+
+func TestFindProtectedResources(t *testing.T) {
+	t.Parallel()
+	result := ParseResult{
+		CreatedResources:  []string{"module.prod_db.aws_db_parameter_group.new"},
+		UpdatedResources:  []string{"aws_s3_bucket.state"},
+		DeletedResources:  []string{"module.prod_db.aws_db_instance.main", "aws_instance.web"},
+		ReplacedResources: []string{"aws_s3_bucket.state_backup", "module.dev_db.aws_db_instance.main"},
+	}
+	protected := FindProtectedResources(result, []string{"module.prod_db.*", "aws_s3_bucket.state*"})
+	if diff := cmp.Diff(protected, []string{"module.prod_db.aws_db_instance.main", "aws_s3_bucket.state_backup"}); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestFindProtectedResourcesForEach(t *testing.T) {
+	t.Parallel()
+	result := ParseResult{
+		DeletedResources: []string{
+			`module.db["prod"].aws_db_instance.main`,
+			`module.db["dev"].aws_db_instance.main`,
+			`aws_s3_bucket.this["logs/prod"]`,
+		},
+		ReplacedResources: []string{`module.db["prod"].module.replica[0].aws_db_instance.main`},
+	}
+	protected := FindProtectedResources(result, []string{`module.db["prod"].*`, `aws_s3_bucket.this["*/prod"]`})
+	if diff := cmp.Diff(protected, []string{
+		`module.db["prod"].aws_db_instance.main`,
+		`aws_s3_bucket.this["logs/prod"]`,
+		`module.db["prod"].module.replica[0].aws_db_instance.main`,
+	}); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestMatchAddressPattern(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		pattern string
+		s       string
+		exp     bool
+	}{
+		{pattern: "aws_iam_*", s: "aws_iam_role", exp: true},
+		{pattern: "aws_iam_*", s: "aws_kms_key"},
+		{pattern: "google_*_iam_*", s: "google_project_iam_member", exp: true},
+		{pattern: "module.vpc", s: "module.vpc", exp: true},
+		{pattern: "module.vpc", s: "module.vpc2"},
+		{pattern: "prod/*", s: "prod/network/vpc", exp: true},
+		{pattern: `module.db["prod"]`, s: `module.db["prod"]`, exp: true},
+		{pattern: `module.db[*]`, s: `module.db["prod"]`, exp: true},
+		{pattern: `module.db["prod"].*`, s: `module.db["dev"].aws_db_instance.main`},
+		{pattern: "*", s: "", exp: true},
+		{pattern: "**.main", s: "aws_db_instance.main", exp: true},
+	}
+	for _, testCase := range testCases {
+		if b := MatchAddressPattern(testCase.pattern, testCase.s); b != testCase.exp {
+			t.Errorf("MatchAddressPattern(%q, %q): wanted %v, got %v", testCase.pattern, testCase.s, testCase.exp, b)
+		}
+	}
+	if ValidateAddressPatterns([]string{"module.vpc", " "}) == nil {
+		t.Error("empty patterns must be invalid")
+	}
+}
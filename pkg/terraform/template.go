@@ -14,12 +14,19 @@ const (
 	DefaultPlanTemplate = `
 {{template "plan_title" .}}
 
-{{if .Link}}[CI link]({{avoidHTMLEscape .Link}}){{end}}
+{{if .Link}}[CI link]({{avoidHTMLEscape .Link}}){{end}}{{template "run_link" .}}
 
 {{template "ai_summary" .}}
+{{template "protected_resources" .}}
 {{template "deletion_warning" .}}
+{{template "risk" .}}
 {{template "result" .}}
 {{template "updated_resources" .}}
+{{template "output_changes" .}}
+{{template "read_resources" .}}
+{{template "cost_estimation" .}}
+{{template "cost" .}}
+{{template "policy_checks" .}}
 
 {{template "changed_result" .}}
 {{template "change_outside_terraform" .}}
@@ -30,11 +37,14 @@ const (
 	DefaultApplyTemplate = `
 {{template "apply_title" .}}
 
-{{if .Link}}[CI link]({{avoidHTMLEscape .Link}}){{end}}
+{{if .Link}}[CI link]({{avoidHTMLEscape .Link}}){{end}}{{template "run_link" .}}
 
 {{if ne .ExitCode 0}}{{template "guide_apply_failure" .}}{{template "ai_summary" .}}{{end}}
 
 {{template "result" .}}
+{{template "applied_resources" .}}
+{{template "cost_estimation" .}}
+{{template "policy_checks" .}}
 
 <details><summary>Details (Click me)</summary>
 {{wrapCode .CombinedOutput}}
@@ -64,6 +74,106 @@ It failed to parse the result.
 
 It failed to parse the result.
 
+<details><summary>Details (Click me)</summary>
+{{wrapCode .CombinedOutput}}
+</details>
+`
+
+	// DefaultValidateTemplate is a default template for terraform validate
+	DefaultValidateTemplate = `
+{{template "validate_title" .}}
+
+{{if .Link}}[CI link]({{avoidHTMLEscape .Link}}){{end}}
+
+{{template "result" .}}
+{{template "diagnostic_details" .}}
+{{template "error_messages" .}}`
+
+	// DefaultValidateParseErrorTemplate is a default template for terraform validate parse error
+	DefaultValidateParseErrorTemplate = `
+{{template "validate_title" .}}
+
+{{if .Link}}[CI link]({{avoidHTMLEscape .Link}}){{end}}
+
+It failed to parse the result.
+
+<details><summary>Details (Click me)</summary>
+{{wrapCode .CombinedOutput}}
+</details>
+`
+
+	// DefaultFmtTemplate is a default template for terraform fmt
+	DefaultFmtTemplate = `
+{{template "fmt_title" .}}
+
+{{if .Link}}[CI link]({{avoidHTMLEscape .Link}}){{end}}
+
+{{template "result" .}}
+{{template "unformatted_files" .}}
+{{template "diagnostic_details" .}}
+{{template "error_messages" .}}`
+
+	// DefaultFmtParseErrorTemplate is a default template for terraform fmt parse error
+	DefaultFmtParseErrorTemplate = `
+{{template "fmt_title" .}}
+
+{{if .Link}}[CI link]({{avoidHTMLEscape .Link}}){{end}}
+
+It failed to parse the result.
+
+<details><summary>Details (Click me)</summary>
+{{wrapCode .CombinedOutput}}
+</details>
+`
+
+	// DefaultDriftTemplate is a default template for drift detection
+	DefaultDriftTemplate = `
+{{template "drift_title" .}}
+
+{{if .Link}}[CI link]({{avoidHTMLEscape .Link}}){{end}}
+
+{{template "result" .}}
+{{template "drifted_resources" .}}
+{{if .ChangeOutsideTerraform}}
+<details><summary>Details (Click me)</summary>
+{{wrapCode .ChangeOutsideTerraform}}
+</details>
+{{end}}
+{{template "diagnostic_details" .}}
+{{template "error_messages" .}}`
+
+	// DefaultDriftParseErrorTemplate is a default template for drift detection parse error
+	DefaultDriftParseErrorTemplate = `
+{{template "drift_title" .}}
+
+{{if .Link}}[CI link]({{avoidHTMLEscape .Link}}){{end}}
+
+It failed to parse the result.
+
+<details><summary>Details (Click me)</summary>
+{{wrapCode .CombinedOutput}}
+</details>
+`
+
+	// DefaultTestTemplate is a default template for terraform test
+	DefaultTestTemplate = `
+{{template "test_title" .}}
+
+{{if .Link}}[CI link]({{avoidHTMLEscape .Link}}){{end}}
+
+{{template "result" .}}
+{{template "test_results" .}}
+{{template "test_failures" .}}
+{{template "error_messages" .}}`
+
+	// DefaultTestParseErrorTemplate is a default template for terraform test parse error
+	DefaultTestParseErrorTemplate = `
+{{template "test_title" .}}
+
+{{if .Link}}[CI link]({{avoidHTMLEscape .Link}}){{end}}
+
+It failed to parse the result.
+
 <details><summary>Details (Click me)</summary>
 {{wrapCode .CombinedOutput}}
 </details>
@@ -97,13 +207,61 @@ type CommonTemplate struct {
 	ReplacedResources      []string
 	MovedResources         []*MovedResource
 	ImportedResources      []string
+	// ForgottenResources is the resources removed from the state by `removed` blocks without being destroyed
+	ForgottenResources []string
+	// DeferredResources is the resources whose changes are deferred to the next plan
+	DeferredResources []string
+	// ReadResources is the data sources which will be read during apply
+	ReadResources []*ReadResource
+	// CreatedAddresses and so on are the parsed addresses of CreatedResources and so on.
+	// They can be grouped with the template functions groupByModule, groupByType, and groupByProvider.
+	CreatedAddresses  []*ResourceAddress
+	UpdatedAddresses  []*ResourceAddress
+	DeletedAddresses  []*ResourceAddress
+	ReplacedAddresses []*ResourceAddress
+	ImportedAddresses []*ResourceAddress
+	// ImportCount and so on are the numbers of the summary line `Plan: 1 to import, 2 to add, ...`
+	ImportCount  int
+	AddCount     int
+	ChangeCount  int
+	DestroyCount int
+	MoveCount    int
+	ForgetCount  int
+	// ResourceChanges is the attribute-level changes of resources.
+	// It isn't rendered by the default templates, but the `resource_changes` template is available.
+	ResourceChanges []*ResourceChange
+	// OutputChanges is the changes of output values
+	OutputChanges []*OutputChange
+	Diagnostics   []*Diagnostic
+	// UnformattedFiles is the files reported by terraform fmt -check
+	UnformattedFiles []*UnformattedFile
+	// AppliedResources is the outcome of each resource in terraform apply
+	AppliedResources []*AppliedResource
+	// DriftedResources is the resources changed outside of Terraform
+	DriftedResources []*DriftedResource
+	// TestFiles is the results of test files of terraform test
+	TestFiles []*TestFile
+	// RunURL is the URL of the run in HCP Terraform or Terraform Enterprise
+	RunURL string
+	// PolicyChecks is the Sentinel policy results of the remote run
+	PolicyChecks []*PolicyCheck
+	// CostEstimation is the cost estimation of the remote run
+	CostEstimation *CostEstimation
+	// Cost is the monthly cost estimated by Infracost
+	Cost *Cost
+	// Risk is the risk of the plan evaluated with the rules
+	Risk *Risk
+	// ProtectedResources is the protected resources which will be deleted or replaced
+	ProtectedResources []string
 	// ModuleResults is populated by TerragruntParser in consolidated mode when
 	// the parsed body contains multiple Terragrunt modules. The default
 	// `updated_resources` template renders a per-module Create/Update/Delete
 	// summary when this is non-empty, falling back to the flat lists otherwise.
-	ModuleResults []*ModuleResult
+	ModuleResults  []*ModuleResult
 	AISummary      string
 	SummaryEnabled bool
+	// Parser is the kind of the output detected automatically, e.g. "terraform", "terragrunt", "remote", and "json"
+	Parser string
 }
 
 // Template is a default template for terraform commands
@@ -151,6 +309,86 @@ func NewApplyParseErrorTemplate(template string) *Template {
 	}
 }
 
+// NewValidateTemplate is ValidateTemplate initializer
+func NewValidateTemplate(template string) *Template {
+	if template == "" {
+		template = DefaultValidateTemplate
+	}
+	return &Template{
+		Template: template,
+	}
+}
+
+// NewValidateParseErrorTemplate is ValidateParseErrorTemplate initializer
+func NewValidateParseErrorTemplate(template string) *Template {
+	if template == "" {
+		template = DefaultValidateParseErrorTemplate
+	}
+	return &Template{
+		Template: template,
+	}
+}
+
+// NewFmtTemplate is FmtTemplate initializer
+func NewFmtTemplate(template string) *Template {
+	if template == "" {
+		template = DefaultFmtTemplate
+	}
+	return &Template{
+		Template: template,
+	}
+}
+
+// NewFmtParseErrorTemplate is FmtParseErrorTemplate initializer
+func NewFmtParseErrorTemplate(template string) *Template {
+	if template == "" {
+		template = DefaultFmtParseErrorTemplate
+	}
+	return &Template{
+		Template: template,
+	}
+}
+
+// NewDriftTemplate is DriftTemplate initializer
+func NewDriftTemplate(template string) *Template {
+	if template == "" {
+		template = DefaultDriftTemplate
+	}
+	return &Template{
+		Template: template,
+	}
+}
+
+// NewDriftParseErrorTemplate is DriftParseErrorTemplate initializer
+func NewDriftParseErrorTemplate(template string) *Template {
+	if template == "" {
+		template = DefaultDriftParseErrorTemplate
+	}
+	return &Template{
+		Template: template,
+	}
+}
+
+// NewTestTemplate is TestTemplate initializer
+func NewTestTemplate(template string) *Template {
+	if template == "" {
+		template = DefaultTestTemplate
+	}
+	return &Template{
+		Template: template,
+	}
+}
+
+// NewTestParseErrorTemplate is TestParseErrorTemplate initializer
+func NewTestParseErrorTemplate(template string) *Template {
+	if template == "" {
+		template = DefaultTestParseErrorTemplate
+	}
+	return &Template{
+		Template: template,
+	}
+}
+
 func avoidHTMLEscape(text string) htmltemplate.HTML {
 	return htmltemplate.HTML(text) //nolint:gosec
 }
@@ -181,6 +419,14 @@ func wrapCode(text string) any {
 	return htmltemplate.HTML(header + "\n```hcl\n" + text + "\n```\n") //nolint:gosec
 }
 
+// wrapDiff is like wrapCode, but highlights the text as a unified diff
+func wrapDiff(text string) any {
+	if strings.Contains(text, "```") || len(text) > 60000 { //nolint:mnd
+		return wrapCode(text)
+	}
+	return htmltemplate.HTML("\n```diff\n" + text + "\n```\n") //nolint:gosec
+}
+
 func generateOutput(kind, template string, data map[string]any, useRawOutput bool) (string, error) {
 	var b bytes.Buffer
 
@@ -189,6 +435,10 @@ func generateOutput(kind, template string, data map[string]any, useRawOutput boo
 			"avoidHTMLEscape": avoidHTMLEscape,
 			"escapeHTML":      escapeHTML,
 			"wrapCode":        wrapCode,
+			"wrapDiff":        wrapDiff,
+			"groupByModule":   groupByModule,
+			"groupByType":     groupByType,
+			"groupByProvider": groupByProvider,
 		}).Funcs(tmpl.TxtFuncMap()).Parse(template)
 		if err != nil {
 			return "", err
@@ -201,6 +451,10 @@ func generateOutput(kind, template string, data map[string]any, useRawOutput boo
 			"avoidHTMLEscape": avoidHTMLEscape,
 			"escapeHTML":      escapeHTML,
 			"wrapCode":        wrapCode,
+			"wrapDiff":        wrapDiff,
+			"groupByModule":   groupByModule,
+			"groupByType":     groupByType,
+			"groupByProvider": groupByProvider,
 		}).Funcs(tmpl.FuncMap()).Parse(template)
 		if err != nil {
 			return "", err
@@ -234,10 +488,38 @@ func (t *Template) Execute() (string, error) {
 		"ReplacedResources":      t.ReplacedResources,
 		"MovedResources":         t.MovedResources,
 		"ImportedResources":      t.ImportedResources,
+		"ForgottenResources":     t.ForgottenResources,
+		"DeferredResources":      t.DeferredResources,
+		"ReadResources":          t.ReadResources,
+		"CreatedAddresses":       t.CreatedAddresses,
+		"UpdatedAddresses":       t.UpdatedAddresses,
+		"DeletedAddresses":       t.DeletedAddresses,
+		"ReplacedAddresses":      t.ReplacedAddresses,
+		"ImportedAddresses":      t.ImportedAddresses,
+		"ImportCount":            t.ImportCount,
+		"AddCount":               t.AddCount,
+		"ChangeCount":            t.ChangeCount,
+		"DestroyCount":           t.DestroyCount,
+		"MoveCount":              t.MoveCount,
+		"ForgetCount":            t.ForgetCount,
+		"ResourceChanges":        t.ResourceChanges,
+		"OutputChanges":          t.OutputChanges,
+		"Diagnostics":            t.Diagnostics,
+		"UnformattedFiles":       t.UnformattedFiles,
+		"AppliedResources":       t.AppliedResources,
+		"DriftedResources":       t.DriftedResources,
+		"TestFiles":              t.TestFiles,
+		"RunURL":                 t.RunURL,
+		"PolicyChecks":           t.PolicyChecks,
+		"CostEstimation":         t.CostEstimation,
+		"Cost":                   t.Cost,
+		"Risk":                   t.Risk,
+		"ProtectedResources":     t.ProtectedResources,
 		"ModuleResults":          t.ModuleResults,
 		"HasDestroy":             t.HasDestroy,
 		"AISummary":              t.AISummary,
 		"SummaryEnabled":         t.SummaryEnabled,
+		"Parser":                 t.Parser,
 	}
 
 	templates := map[string]string{
@@ -247,6 +529,8 @@ func (t *Template) Execute() (string, error) {
 		"ai_summary":  "{{if .SummaryEnabled}}{{if .AISummary}}<details><summary>AI Summary (Click me)</summary>\n\n{{avoidHTMLEscape .AISummary}}\n\n</details>{{end}}{{end}}",
 		"updated_resources": `{{if .ModuleResults}}{{range .ModuleResults}}
 ### {{.Module}}
+
+{{with .ParseResult}}Plan: {{.AddCount}} to add, {{.ChangeCount}} to change, {{.DestroyCount}} to destroy.{{end}}
 {{if .CreatedResources}}
 * Create
 {{- range .CreatedResources}}
@@ -271,6 +555,14 @@ func (t *Template) Execute() (string, error) {
 * Move
 {{- range .MovedResources}}
   * {{.Before}} => {{.After}}
+{{- end}}{{end}}{{if .ForgottenResources}}
+* Forget
+{{- range .ForgottenResources}}
+  * {{.}}
+{{- end}}{{end}}{{if .DeferredResources}}
+* Deferred
+{{- range .DeferredResources}}
+  * {{.}}
 {{- end}}{{end}}
 {{end}}{{else}}{{if .CreatedResources}}
 * Create
@@ -296,7 +588,27 @@ func (t *Template) Execute() (string, error) {
 * Move
 {{- range .MovedResources}}
   * {{.Before}} => {{.After}}
+{{- end}}{{end}}{{if .ForgottenResources}}
+* Forget
+{{- range .ForgottenResources}}
+  * {{.}}
+{{- end}}{{end}}{{if .DeferredResources}}
+* Deferred
+{{- range .DeferredResources}}
+  * {{.}}
 {{- end}}{{end}}{{end}}`,
+		"resource_changes": `{{if .ResourceChanges}}
+<details><summary>Changed Attributes (Click me)</summary>
+{{range .ResourceChanges}}{{if or (eq .Action "update") (eq .Action "replace")}}
+* {{.Address}} ({{.Action}})
+{{- range .ChangedAttributes}}
+  * {{.Name}}{{if or .Before .After}}: <code>{{.Before}}</code> → <code>{{.After}}</code>{{end}}
+{{- end}}{{if .ForcesReplacement}}
+  * :warning: forces replacement: {{join ", " .ForcesReplacement}}
+{{- end}}{{end}}{{end}}
+
+</details>
+{{end}}`,
 		"deletion_warning": `{{if .HasDestroy}}
 ### :warning: Resource Deletion will happen
 This plan contains resource delete operation. Please check the plan result very carefully!
@@ -322,6 +634,147 @@ _This feature was introduced from [Terraform v0.15.4](https://github.com/hashico
 {{range .ErrorMessages}}
 * {{. -}}
 {{- end}}{{end}}`,
+		"diagnostics": `{{if .Diagnostics}}
+## Diagnostics
+{{range .Diagnostics}}
+* {{if eq .Severity "error"}}:x:{{else}}:warning:{{end}} {{.Summary}}{{if .File}} ({{.File}}:{{.Line}}){{end}}
+{{- end}}{{end}}`,
+		"diagnostic_details": `{{range .Diagnostics}}
+### {{if eq .Severity "error"}}:x: Error{{else}}:warning: Warning{{end}}: {{.Summary}}
+{{if .File}}
+<code>{{.File}}</code> line {{.Line}}
+{{end}}{{if .Snippet}}{{wrapCode .Snippet}}{{end}}{{if .Detail}}
+{{.Detail}}
+{{end}}{{end}}`,
+		"applied_resources": `{{if .AppliedResources}}
+<details{{if ne .ExitCode 0}} open{{end}}><summary>Resources (Click me)</summary>
+
+| Status | Resource | Action | Duration | ID |
+|---|---|---|---|---|
+{{- range .AppliedResources}}
+| {{if eq .Status "succeeded"}}:white_check_mark:{{else if eq .Status "failed"}}:x:{{else}}:grey_question:{{end}} | <code>{{.Address}}</code> | {{.Action}} | {{.Duration}} | {{if .ID}}<code>{{.ID}}</code>{{end}} |
+{{- end}}
+
+</details>{{end}}`,
+		"drifted_resources": `{{range .DriftedResources}}
+* {{if eq .Action "delete"}}:wastebasket: <code>{{.Address}}</code> has been deleted{{else}}:pencil2: <code>{{.Address}}</code> has changed{{end}}
+{{- end}}`,
+		"test_results": `{{if .TestFiles}}
+| File | Run | Status |
+|---|---|---|
+{{- range .TestFiles}}{{$file := .Path}}{{if .Runs}}{{range .Runs}}
+| <code>{{$file}}</code> | {{.Name}} | {{template "test_status" .Status}} |
+{{- end}}{{else}}
+| <code>{{$file}}</code> | | {{template "test_status" .Status}} |
+{{- end}}{{end}}
+{{end}}`,
+		"test_failures": `{{range .TestFiles}}{{$file := .Path}}{{range .Runs}}{{if or (eq .Status "fail") (eq .Status "error")}}
+### :x: <code>{{$file}}</code> run "{{.Name}}"
+{{template "diagnostic_details" .}}
+{{end}}{{end}}{{end}}`,
+		"unformatted_files": `{{range .UnformattedFiles}}
+{{if .Diff}}<details><summary><code>{{.Path}}</code></summary>
+{{wrapDiff .Diff}}
+</details>{{else}}* <code>{{.Path}}</code>{{end}}
+{{- end}}{{if .UnformattedFiles}}
+
+Run <code>terraform fmt -recursive</code> to format them.{{end}}`,
+		"output_changes": `{{if .OutputChanges}}
+<details><summary>Output Changes (Click me)</summary>
+{{range .OutputChanges}}
+* <code>{{.Name}}</code> ({{.Action}}){{$value := .After}}{{if eq .Action "delete"}}{{$value = .Before}}{{end}}{{if contains "\n" $value}}
+{{wrapCode $value}}{{else}}: {{if .Before}}<code>{{.Before}}</code> → {{end}}<code>{{.After}}</code>{{end}}
+{{- end}}
+
+</details>
+{{end}}`,
+		"read_resources": `{{if .ReadResources}}
+<details><summary>Data Sources Read During Apply (Click me)</summary>
+{{range .ReadResources}}
+* {{.Address}}{{if .Reason}} ({{.Reason}}){{end}}
+{{- end}}
+
+</details>
+{{end}}`,
+		"resource_type_summary": `{{if .CreatedAddresses}}
+* Create
+{{- range groupByType .CreatedAddresses}}
+  * {{.Key}} ×{{len .Resources}}
+{{- end}}{{end}}{{if .UpdatedAddresses}}
+* Update
+{{- range groupByType .UpdatedAddresses}}
+  * {{.Key}} ×{{len .Resources}}
+{{- end}}{{end}}{{if .DeletedAddresses}}
+* Delete
+{{- range groupByType .DeletedAddresses}}
+  * {{.Key}} ×{{len .Resources}}
+{{- end}}{{end}}{{if .ReplacedAddresses}}
+* Replace
+{{- range groupByType .ReplacedAddresses}}
+  * {{.Key}} ×{{len .Resources}}
+{{- end}}{{end}}{{if .ImportedAddresses}}
+* Import
+{{- range groupByType .ImportedAddresses}}
+  * {{.Key}} ×{{len .Resources}}
+{{- end}}{{end}}`,
+		"policy_checks": `{{if .PolicyChecks}}
+<details{{range .PolicyChecks}}{{if not .Passed}} open{{break}}{{end}}{{end}}><summary>Sentinel Policy Checks (Click me)</summary>
+
+| Result | Policy | Enforcement Level |
+|---|---|---|
+{{- range .PolicyChecks}}
+| {{if .Passed}}:white_check_mark: passed{{else}}:x: failed{{end}} | <code>{{.Name}}</code> | {{.EnforcementLevel}} |
+{{- end}}
+
+</details>
+{{end}}`,
+		"cost_estimation": `{{with .CostEstimation}}
+:moneybag: Estimated monthly cost: <code>{{.ProposedMonthlyCost}}</code> (<code>{{.DeltaMonthlyCost}}</code>), {{.MatchedResourcesCount}} of {{.ResourcesCount}} resources estimated
+{{end}}`,
+		"protected_resources": `{{if .ProtectedResources}}
+### :no_entry: Protected resources will be destroyed
+This plan deletes or replaces the following protected resources. Please make sure it is intended before applying it!
+{{range .ProtectedResources}}
+* <code>{{.}}</code>
+{{- end}}
+{{end}}`,
+		"risk": `{{with .Risk}}{{if .Reasons}}
+{{if eq .Level "critical"}}:rotating_light:{{else if eq .Level "high"}}:warning:{{else}}:information_source:{{end}} Risk: **{{.Level}}**
+
+{{range .Reasons}}* {{.Level}}: {{.Reason}}
+{{- range .Resources}}
+  * <code>{{.}}</code>
+{{- end}}
+{{end}}{{end}}{{end}}`,
+		"cost": `{{with .Cost}}
+:moneybag: Monthly cost ({{.Currency}}): <code>{{printf "%.2f" .Before}}</code> → <code>{{printf "%.2f" .After}}</code> (<code>{{if gt .Delta 0.0}}+{{end}}{{printf "%.2f" .Delta}}</code>)
+{{if gt (len .Modules) 1}}
+<details><summary>Cost per module (Click me)</summary>
+
+| Module | Before | After | Diff |
+|---|---:|---:|---:|
+{{- range .Modules}}
+| <code>{{.Path}}</code> | {{printf "%.2f" .Before}} | {{printf "%.2f" .After}} | {{if gt .Delta 0.0}}+{{end}}{{printf "%.2f" .Delta}} |
+{{- end}}
+
+</details>
+{{end}}{{if .Resources}}
+<details><summary>Cost per resource (Click me)</summary>
+
+| Resource | Before | After | Diff |
+|---|---:|---:|---:|
+{{- range .Resources}}
+| <code>{{.Address}}</code> | {{printf "%.2f" .Before}} | {{printf "%.2f" .After}} | {{if gt .Delta 0.0}}+{{end}}{{printf "%.2f" .Delta}} |
+{{- end}}
+
+</details>
+{{end}}{{end}}`,
+		"run_link":                "{{if .RunURL}}{{if .Link}} | {{end}}[Run]({{avoidHTMLEscape .RunURL}}){{end}}",
+		"validate_title":          "## {{if or (ne .ExitCode 0) .HasError}}:x: Validation Failed{{else}}:white_check_mark: Validation Succeeded{{end}}{{if .Vars.target}} ({{.Vars.target}}){{end}}",
+		"fmt_title":               "## {{if or (ne .ExitCode 0) .HasError .UnformattedFiles}}:x: Format Check Failed{{else}}:white_check_mark: Format Check Succeeded{{end}}{{if .Vars.target}} ({{.Vars.target}}){{end}}",
+		"drift_title":             "## {{if or .HasError (eq .ExitCode 1)}}:x: Drift Detection Failed{{else if .ChangeOutsideTerraform}}:warning: Drift Detected{{else}}:white_check_mark: No Drift{{end}}{{if .Vars.target}} ({{.Vars.target}}){{end}}",
+		"test_title":              "## {{if or (ne .ExitCode 0) .HasError}}:x: Test Failed{{else}}:white_check_mark: Test Succeeded{{end}}{{if .Vars.target}} ({{.Vars.target}}){{end}}",
+		"test_status":             `{{if eq . "pass"}}:white_check_mark:{{else if eq . "skip"}}:fast_forward:{{else}}:x:{{end}} {{.}}`,
 		"guide_apply_failure":     "",
 		"guide_apply_parse_error": "",
 	}